@@ -1,17 +1,37 @@
 package controllers
 
 import (
+	"context"
+	"errors"
+	"log"
 	"net/http"
 	"project-name/app/middlewares"
+	"project-name/app/models"
 	"project-name/app/repository"
 	"project-name/app/reqres"
+	"project-name/app/session"
 	"project-name/app/utils"
+	"project-name/config"
 	"strconv"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/labstack/echo/v4"
 )
 
+// AuthController serves the /v1/auth endpoints
+type AuthController struct {
+	auth     repository.AuthRepository
+	users    repository.UserRepository
+	regions  repository.RegionRepository
+	roles    repository.RoleRepository
+	uow      repository.UnitOfWork
+	sessions session.Store
+}
+
+func NewAuthController(auth repository.AuthRepository, users repository.UserRepository, regions repository.RegionRepository, roles repository.RoleRepository, uow repository.UnitOfWork, sessions session.Store) *AuthController {
+	return &AuthController{auth: auth, users: users, regions: regions, roles: roles, uow: uow, sessions: sessions}
+}
+
 // LoginUser godoc
 // @Summary Login User
 // @Description Login User
@@ -22,7 +42,8 @@ import (
 // @Success 200
 // @Router /v1/auth/login/user [post]
 // @Security ApiKeyAuth
-func LoginUser(c echo.Context) error {
+func (h *AuthController) LoginUser(c echo.Context) error {
+	ctx := c.Request().Context()
 	var data reqres.LoginRequest
 	if err := c.Bind(&data); err != nil {
 		return utils.NewBadRequestError("Invalid request body")
@@ -33,7 +54,7 @@ func LoginUser(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
 	}
 
-	user, t, err := repository.Login(data.EmailOrPhone)
+	user, err := h.auth.Login(ctx, data.EmailOrPhone)
 	if err != nil {
 		return c.JSON(400, utils.Respond(400, err, "Invalid email"))
 	}
@@ -42,15 +63,25 @@ func LoginUser(c echo.Context) error {
 		return c.JSON(400, utils.Respond(400, err, "Invalid password"))
 	}
 
-	if user.RoleID != 3 {
+	if !h.roles.RoleHasPermission(ctx, user.RoleID, "user.login") {
 		return c.JSON(400, utils.Respond(400, err, "You are not a user"))
 	}
 
-	userResponse, _ := repository.GetUserByID(int(user.ID))
+	if config.LoadConfig().RequireEmailVerification && !user.IsVerify {
+		return c.JSON(http.StatusForbidden, utils.NewForbiddenError("Email is not verified"))
+	}
+
+	t, refreshToken, err := h.auth.StartSession(ctx, user, c.Request().Header.Get("X-Device-Name"), c.Request().UserAgent(), c.RealIP())
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to create session"))
+	}
+
+	userResponse, _ := h.users.GetUserByID(ctx, int(user.ID))
 
 	dataResponse := reqres.LoginResponse{
-		Token: t,
-		User:  userResponse,
+		Token:        t,
+		RefreshToken: refreshToken,
+		User:         userResponse,
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -70,7 +101,8 @@ func LoginUser(c echo.Context) error {
 // @Success 200
 // @Router /v1/auth/login/admin [post]
 // @Security ApiKeyAuth
-func LoginAdmin(c echo.Context) error {
+func (h *AuthController) LoginAdmin(c echo.Context) error {
+	ctx := c.Request().Context()
 	var data reqres.LoginRequest
 	if err := c.Bind(&data); err != nil {
 		return utils.NewBadRequestError("Invalid request body")
@@ -81,7 +113,7 @@ func LoginAdmin(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
 	}
 
-	user, t, err := repository.Login(data.EmailOrPhone)
+	user, err := h.auth.Login(ctx, data.EmailOrPhone)
 	if err != nil {
 		return c.JSON(400, utils.Respond(400, err, "Invalid email"))
 	}
@@ -90,15 +122,25 @@ func LoginAdmin(c echo.Context) error {
 		return c.JSON(400, utils.Respond(400, err, "Invalid password"))
 	}
 
-	if user.RoleID == 3 {
+	if !h.roles.RoleHasPermission(ctx, user.RoleID, "admin.login") {
 		return c.JSON(400, utils.Respond(400, err, "You are not admin"))
 	}
 
-	userResponse, _ := repository.GetUserByID(int(user.ID))
+	if config.LoadConfig().RequireEmailVerification && !user.IsVerify {
+		return c.JSON(http.StatusForbidden, utils.NewForbiddenError("Email is not verified"))
+	}
+
+	t, refreshToken, err := h.auth.StartSession(ctx, user, c.Request().Header.Get("X-Device-Name"), c.Request().UserAgent(), c.RealIP())
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to create session"))
+	}
+
+	userResponse, _ := h.users.GetUserByID(ctx, int(user.ID))
 
 	dataResponse := reqres.LoginResponse{
-		Token: t,
-		User:  userResponse,
+		Token:        t,
+		RefreshToken: refreshToken,
+		User:         userResponse,
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -108,6 +150,48 @@ func LoginAdmin(c echo.Context) error {
 	})
 }
 
+// RefreshToken godoc
+// @Summary Refresh Token
+// @Description Exchange a refresh token for a new access token. The refresh token is rotated on every call.
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Param request body reqres.RefreshTokenRequest true "Refresh Token Request"
+// @Success 200
+// @Router /v1/auth/refresh [post]
+func (h *AuthController) RefreshToken(c echo.Context) error {
+	ctx := c.Request().Context()
+	var data reqres.RefreshTokenRequest
+	if err := c.Bind(&data); err != nil {
+		return c.JSON(400, utils.NewUnprocessableEntityError(err.Error()))
+	}
+
+	if err := data.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
+	}
+
+	_, accessToken, refreshToken, err := h.auth.RotateRefreshToken(ctx, data.RefreshToken, c.Request().UserAgent(), c.RealIP())
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrInvalidRefreshToken),
+			errors.Is(err, utils.ErrExpiredRefreshToken),
+			errors.Is(err, utils.ErrRefreshTokenReused):
+			return c.JSON(http.StatusUnauthorized, utils.NewUnauthorizedError(err.Error()))
+		}
+		return c.JSON(500, utils.Respond(500, err, "Failed to refresh token"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": 200,
+		"data": reqres.RefreshTokenResponse{
+			Token:        accessToken,
+			RefreshToken: refreshToken,
+		},
+		"message": "Refresh Token Success",
+	})
+}
+
 // Register godoc
 // @Summary Register
 // @Description Register
@@ -117,18 +201,22 @@ func LoginAdmin(c echo.Context) error {
 // @Param request body reqres.UserRequest true "Register Request"
 // @Success 200
 // @Router /v1/auth/register [post]
-func Register(c echo.Context) error {
+func (h *AuthController) Register(c echo.Context) error {
+	ctx := c.Request().Context()
 	var data reqres.UserRequest
 	if err := c.Bind(&data); err != nil {
 		return c.JSON(400, utils.NewUnprocessableEntityError(err.Error()))
 	}
 
-	if err := data.Validate(); err != nil {
-		errVal := err.(validation.Errors)
+	err := data.Validate(ctx, h.regions)
+	if errVal, ok := err.(validation.Errors); ok {
 		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
 	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to validate user"))
+	}
 
-	users, _ := repository.GetAllUsers()
+	users, _ := h.users.GetAllUsers(ctx)
 
 	for _, dataUser := range users {
 		if dataUser.Email == data.Email {
@@ -140,18 +228,19 @@ func Register(c echo.Context) error {
 		}
 	}
 
-	user, err := repository.Register(data)
+	user, err := h.auth.Register(ctx, data)
+	if err == repository.ErrFileUnavailable {
+		return c.JSON(400, utils.NewBadRequestError("Image cannot be set on registration, upload an avatar after signing in"))
+	}
 	if err != nil {
 		return c.JSON(500, utils.Respond(500, err, "Failed to create user"))
 	}
 
-	userResponse, err := repository.GetUserByID(int(user.ID))
+	userResponse, err := h.users.GetUserByID(ctx, int(user.ID))
 	if err != nil {
 		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
 	}
 
-	// go repository.SendEmailVerificationEmail(int(user.ID))
-
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"status":  200,
 		"data":    userResponse,
@@ -161,97 +250,112 @@ func Register(c echo.Context) error {
 
 // ForgotPassword godoc
 // @Summary Forgot Password
-// @Description Forgot Password
+// @Description Email a single use reset password link. The response is the same whether the email is registered or not.
 // @Tags Auth
 // @Accept  json
 // @Produce  json
 // @Param request body reqres.ForgotPasswordRequest true "Forgot Password Request"
 // @Success 200
 // @Router /v1/auth/forgot-password [post]
-func ForgotPassword(c echo.Context) error {
+func (h *AuthController) ForgotPassword(c echo.Context) error {
+	ctx := c.Request().Context()
 	var data reqres.ForgotPasswordRequest
 	if err := c.Bind(&data); err != nil {
 		return c.JSON(400, utils.NewUnprocessableEntityError(err.Error()))
 	}
 
-	user, err := repository.GetUserByEmail(data.Email)
-	if err != nil {
-		return c.JSON(400, utils.Respond(400, err, "Email not found"))
+	if err := data.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
 	}
 
-	// go repository.UserForgotPasswordNotification(int(user.ID))
+	// a failure is only logged, an error response would tell that the email is registered
+	user, err := h.users.GetUserByEmail(ctx, data.Email)
+	if err == nil {
+		if err := h.auth.RequestPasswordReset(ctx, user, c.RealIP()); err != nil {
+			log.Printf("Failed to create reset password request for user %d. Error: %v", user.ID, err)
+		}
+	}
 
 	return c.JSON(200, map[string]interface{}{
 		"status":  200,
-		"data":    user,
 		"message": "Permintaan Reset Password Berhasil Silahkan Cek Email Anda",
 	})
 }
 
 // ResetPassword godoc
 // @Summary Reset Password
-// @Description Reset Password
+// @Description Set a new password with the token from the forgot password email
 // @Tags Auth
 // @Accept  json
 // @Produce  json
-// @Param req body reqres.ChangePassword true "Reset Password Request"
+// @Param req body reqres.ResetPasswordRequest true "Reset Password Request"
 // @Success 200
-// @Router /v1/auth/reset-password/{id} [put]
-func ResetPassword(c echo.Context) error {
-	id, _ := strconv.Atoi(c.Param("id"))
-
-	data, err := repository.GetUserByIDPlain(id)
-	if err != nil {
-		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
-	}
-
-	var req reqres.ChangePassword
+// @Router /v1/auth/reset-password [put]
+func (h *AuthController) ResetPassword(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req reqres.ResetPasswordRequest
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(400, utils.NewUnprocessableEntityError(err.Error()))
 	}
 
-	if req.NewPassword == "" || req.NewPasswordConfirm == "" {
-		return c.JSON(400, utils.NewUnprocessableEntityError("New Password and New Password Confirm cannot be empty"))
-	}
-
-	if req.NewPassword != req.NewPasswordConfirm {
-		return c.JSON(400, utils.NewUnprocessableEntityError("New Password and New Password Confirm must be same"))
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
 	}
 
-	newPassword := middlewares.BcryptPassword(req.NewPassword)
-
-	data.Password = newPassword
+	// the new password only sticks if every session could be revoked
+	err := h.uow.WithTx(ctx, func(ctx context.Context) error {
+		user, err := h.auth.ResetPasswordWithToken(ctx, req.Token, req.NewPassword)
+		if err != nil {
+			return err
+		}
 
-	update, err := repository.UpdateUser(data)
-	if err != nil {
-		return c.JSON(500, utils.Respond(500, err, "Failed to update user"))
+		return h.auth.RevokeAllSessions(ctx, int(user.ID), "")
+	})
+	if errors.Is(err, utils.ErrInvalidResetToken) {
+		return c.JSON(400, utils.NewBadRequestError(err.Error()))
 	}
-
-	dataUpdate, err := repository.GetUserByID(int(update.ID))
 	if err != nil {
-		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
+		return c.JSON(500, utils.Respond(500, err, "Failed to reset password"))
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"status":  200,
-		"data":    dataUpdate,
 		"message": "Password Berhasil Diubah",
 	})
 }
 
 // SendEmailVerifyEmail godoc
 // @Summary Send Email Verify Email
-// @Description Send Email Verify Email
+// @Description Send a new verification link to the current user's email
 // @Tags Auth
 // @Accept  json
 // @Produce  json
 // @Success 200
 // @Router /v1/auth/email-verify [post]
 // @Security JwtToken
-func SendEmailVerifyEmail(c echo.Context) error {
-	// userID := c.Get("user_id").(int)
+func (h *AuthController) SendEmailVerifyEmail(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Get("user_id").(int)
 
-	// go repository.SendEmailVerificationEmail(userID)
+	user, err := h.users.GetUserByIDPlain(ctx, userID)
+	if err != nil {
+		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
+	}
+
+	if user.IsVerify {
+		return c.JSON(400, utils.NewBadRequestError("Email already verified"))
+	}
+
+	retryAfter, err := h.auth.RequestEmailVerification(ctx, user)
+	if errors.Is(err, utils.ErrTooManyRequests) {
+		c.Response().Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+		return c.JSON(http.StatusTooManyRequests, utils.NewTooManyRequestsError("Please wait before requesting another verification email"))
+	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to create verification request"))
+	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"status":  200,
@@ -259,30 +363,68 @@ func SendEmailVerifyEmail(c echo.Context) error {
 	})
 }
 
-// AktivateAccount godoc
-// @Summary Aktivate Account
-// @Description Aktivate Account
+// ResendVerificationEmail godoc
+// @Summary Resend Verification Email
+// @Description Send a new verification link without being logged in. The response is the same whether the email is registered or not.
 // @Tags Auth
 // @Accept  json
 // @Produce  json
+// @Param request body reqres.ResendVerificationRequest true "Resend Verification Request"
 // @Success 200
-// @Router /v1/auth/activate-account/{id} [put]
-func AktivateAccount(c echo.Context) error {
-	userID, _ := strconv.Atoi(c.Param("id"))
+// @Router /v1/auth/resend-verification [post]
+func (h *AuthController) ResendVerificationEmail(c echo.Context) error {
+	ctx := c.Request().Context()
+	var data reqres.ResendVerificationRequest
+	if err := c.Bind(&data); err != nil {
+		return c.JSON(400, utils.NewUnprocessableEntityError(err.Error()))
+	}
 
-	data, err := repository.GetUserByIDPlain(userID)
-	if err != nil {
-		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
+	if err := data.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
+	}
+
+	user, err := h.users.GetUserByEmail(ctx, data.Email)
+	if err == nil && !user.IsVerify {
+		h.auth.RequestEmailVerification(ctx, user)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  200,
+		"message": "Permintaan Verifikasi Email Berhasil Silahkan Cek Email Anda",
+	})
+}
+
+// VerifyEmail godoc
+// @Summary Verify Email
+// @Description Verify the email address with the token from the verification email
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Param request body reqres.VerifyEmailRequest true "Verify Email Request"
+// @Success 200
+// @Router /v1/auth/verify-email [post]
+func (h *AuthController) VerifyEmail(c echo.Context) error {
+	ctx := c.Request().Context()
+	var data reqres.VerifyEmailRequest
+	if err := c.Bind(&data); err != nil {
+		return c.JSON(400, utils.NewUnprocessableEntityError(err.Error()))
 	}
 
-	data.IsVerify = true
+	if err := data.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
+	}
 
-	update, err := repository.UpdateUser(data)
+	user, err := h.auth.VerifyEmailWithToken(ctx, data.Token)
+	if errors.Is(err, utils.ErrInvalidVerifyToken) || errors.Is(err, utils.ErrExistsEmailError) {
+		return c.JSON(400, utils.NewBadRequestError(err.Error()))
+	}
 	if err != nil {
-		return c.JSON(500, utils.Respond(500, err, "Failed to update user"))
+		return c.JSON(500, utils.Respond(500, err, "Failed to verify email"))
 	}
 
-	dataUpdate, err := repository.GetUserByID(int(update.ID))
+	dataUpdate, err := h.users.GetUserByID(ctx, int(user.ID))
 	if err != nil {
 		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
 	}
@@ -290,7 +432,7 @@ func AktivateAccount(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"status":  200,
 		"data":    dataUpdate,
-		"message": "Permintaan Verifikasi Email Berhasil",
+		"message": "Verifikasi Email Berhasil",
 	})
 }
 
@@ -304,10 +446,11 @@ func AktivateAccount(c echo.Context) error {
 // @Success 200
 // @Router /v1/auth/change-password-login [put]
 // @Security JwtToken
-func ChangePasswordLogin(c echo.Context) error {
+func (h *AuthController) ChangePasswordLogin(c echo.Context) error {
+	ctx := c.Request().Context()
 	userID := c.Get("user_id").(int)
 
-	data, err := repository.GetUserByIDPlain(userID)
+	data, err := h.users.GetUserByIDPlain(ctx, userID)
 	if err != nil {
 		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
 	}
@@ -329,12 +472,19 @@ func ChangePasswordLogin(c echo.Context) error {
 
 	data.Password = newPassword
 
-	update, err := repository.UpdateUser(data)
+	var update models.User
+	err = h.uow.WithTx(ctx, func(ctx context.Context) (err error) {
+		if update, err = h.users.UpdateUser(ctx, data, userID); err != nil {
+			return
+		}
+
+		return h.auth.RevokeAllSessions(ctx, userID, c.Get("session_id").(string))
+	})
 	if err != nil {
-		return c.JSON(500, utils.Respond(500, err, "Failed to update user"))
+		return c.JSON(500, utils.Respond(500, err, "Failed to update password"))
 	}
 
-	dataUpdate, err := repository.GetUserByID(int(update.ID))
+	dataUpdate, err := h.users.GetUserByID(ctx, int(update.ID))
 	if err != nil {
 		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
 	}
@@ -345,3 +495,108 @@ func ChangePasswordLogin(c echo.Context) error {
 		"message": "Password Berhasil Diubah",
 	})
 }
+
+// Logout godoc
+// @Summary Logout
+// @Description Revoke the current session
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Success 200
+// @Router /v1/auth/logout [post]
+// @Security JwtToken
+func (h *AuthController) Logout(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Get("user_id").(int)
+	sessionID := c.Get("session_id").(string)
+
+	if err := h.auth.RevokeSession(ctx, userID, sessionID); err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to logout"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  200,
+		"message": "Logout Success",
+	})
+}
+
+// LogoutAll godoc
+// @Summary Logout All
+// @Description Revoke every session of the current user, including this one
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Success 200
+// @Router /v1/auth/logout-all [post]
+// @Security JwtToken
+func (h *AuthController) LogoutAll(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Get("user_id").(int)
+
+	if err := h.auth.RevokeAllSessions(ctx, userID, ""); err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to logout"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  200,
+		"message": "Logout All Success",
+	})
+}
+
+// GetSessions godoc
+// @Summary Get Sessions
+// @Description List active sessions of the current user
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Success 200
+// @Router /v1/auth/sessions [get]
+// @Security JwtToken
+func (h *AuthController) GetSessions(c echo.Context) error {
+	userID := c.Get("user_id").(int)
+	sessionID := c.Get("session_id").(string)
+
+	sessions, err := session.ListActive(c.Request().Context(), h.sessions, userID)
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to get sessions"))
+	}
+
+	data := []reqres.SessionResponse{}
+	for _, s := range sessions {
+		data = append(data, repository.BuildSessionResponse(s, sessionID))
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  200,
+		"data":    data,
+		"message": "Get Sessions Success",
+	})
+}
+
+// RevokeSession godoc
+// @Summary Revoke Session
+// @Description Revoke one session of the current user
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Session ID"
+// @Success 200
+// @Router /v1/auth/sessions/{id} [delete]
+// @Security JwtToken
+func (h *AuthController) RevokeSession(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Get("user_id").(int)
+
+	err := h.auth.RevokeSession(ctx, userID, c.Param("id"))
+	if errors.Is(err, session.ErrSessionNotFound) {
+		return c.JSON(http.StatusNotFound, utils.NewNotFoundError("Session not found"))
+	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to revoke session"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  200,
+		"message": "Revoke Session Success",
+	})
+}
@@ -0,0 +1,265 @@
+package controllers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"project-name/app/middlewares"
+	"project-name/app/models"
+	"project-name/app/reqres"
+	"project-name/app/storage"
+	"project-name/app/utils"
+	"project-name/config"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// DownloadFile godoc
+// @Summary Download File
+// @Description Download a file, or one of its image variants, with support for Range requests. Either send the token of the owner or of a user with the file.read permission, or use a link from Share File, which needs neither the token nor the API key.
+// @Tags File
+// @Produce application/octet-stream
+// @Param id path int true "File ID"
+// @Param variant query string false "Image variant, e.g. thumb"
+// @Param disposition query string false "attachment (default) or inline, which only applies to JPEG, PNG and WebP images"
+// @Param expires query int false "Expiry of a shared link"
+// @Param signature query string false "Signature of a shared link"
+// @Param Range header string false "Byte range, e.g. bytes=0-1023"
+// @Success 200
+// @Success 206
+// @Router /v1/files/{id}/download [get]
+// @Security JwtToken
+func (h *FileController) DownloadFile(c echo.Context) error {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	if c.QueryParam("signature") != "" {
+		if !verifyDownloadSignature(id, c.QueryParam("variant"), c.QueryParam("expires"), c.QueryParam("signature")) {
+			return c.JSON(http.StatusForbidden, utils.NewForbiddenError("The link is invalid or has expired"))
+		}
+
+		return h.serveFile(c, id, false)
+	}
+
+	// without a signature this is a regular API call
+	return middlewares.CheckAPIKey()(middlewares.Auth(h.sessions)(func(c echo.Context) error {
+		return h.serveFile(c, id, true)
+	}))(c)
+}
+
+// ShareFile godoc
+// @Summary Share File
+// @Description Create a signed link downloading the file, or one of its image variants, without signing in until it expires
+// @Tags File
+// @Accept  json
+// @Produce  json
+// @Param id path int true "File ID"
+// @Param request body reqres.FileShareRequest true "Share File Request"
+// @Success 200
+// @Router /v1/files/{id}/share [post]
+// @Security JwtToken
+func (h *FileController) ShareFile(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	var req reqres.FileShareRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(400, utils.NewUnprocessableEntityError(err.Error()))
+	}
+	if req.ExpiresIn == 0 {
+		req.ExpiresIn = config.LoadConfig().StorageURLTTL
+	}
+	if err := req.Validate(config.LoadConfig().FileShareMaxTTL); err != nil {
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(err.(validation.Errors)))
+	}
+
+	data, err := h.files.GetFileByID(ctx, id)
+	if err == nil && !h.canReadFile(c, data) {
+		err = gorm.ErrRecordNotFound
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, utils.NewNotFoundError("File not found"))
+	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to get file"))
+	}
+
+	if _, ok := fileVariant(data, req.Variant); !ok {
+		return c.JSON(http.StatusNotFound, utils.NewNotFoundError("Variant not found"))
+	}
+
+	expiresAt := time.Now().Add(time.Duration(req.ExpiresIn) * time.Second)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	query := url.Values{"expires": {expires}, "signature": {downloadSignature(id, req.Variant, expires)}}
+	if req.Variant != "" {
+		query.Set("variant", req.Variant)
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status": 200,
+		"data": map[string]interface{}{
+			"url":        fmt.Sprintf("%s/v1/files/%d/download?%s", strings.TrimSuffix(config.LoadConfig().BaseUrl, "/"), id, query.Encode()),
+			"expires_at": expiresAt,
+		},
+		"message": "Share File Success",
+	})
+}
+
+// ServeStorageObject serves the signed URLs of the local storage backend, see
+// storage.Local.SignedURL. They are embedded in API responses, e.g. as avatars.
+// Only objects of clean files are served, with the MIME type sniffed at upload.
+func (h *FileController) ServeStorageObject(c echo.Context) error {
+	local, ok := storage.Default.(*storage.Local)
+	if !ok {
+		return c.JSON(http.StatusNotFound, utils.NewNotFoundError("Not found"))
+	}
+
+	key, err := url.PathUnescape(c.Param("*"))
+	if err != nil || !local.VerifySignature(key, c.QueryParam("expires"), c.QueryParam("signature")) {
+		return c.JSON(http.StatusForbidden, utils.NewForbiddenError("The link is invalid or has expired"))
+	}
+
+	data, err := h.files.GetFileByKey(c.Request().Context(), key)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, utils.NewNotFoundError("File not found"))
+	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to get file"))
+	}
+	if data.ScanStatus != models.FileScanClean {
+		return c.JSON(http.StatusForbidden, utils.NewForbiddenError("The file has not passed the malware scan"))
+	}
+
+	contentType := data.MimeType
+	for _, variant := range data.Variants {
+		if variant.Key == key {
+			contentType = variant.MimeType
+		}
+	}
+
+	return serveObject(c, key, contentType, path.Base(key), "inline")
+}
+
+// serveFile streams the file, checking that the user may read it when checkAccess is set
+func (h *FileController) serveFile(c echo.Context, id int, checkAccess bool) error {
+	data, err := h.files.GetFileByID(c.Request().Context(), id)
+	if err == nil && checkAccess && !h.canReadFile(c, data) {
+		err = gorm.ErrRecordNotFound
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, utils.NewNotFoundError("File not found"))
+	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to get file"))
+	}
+
+	if data.ScanStatus != models.FileScanClean {
+		return c.JSON(http.StatusForbidden, utils.NewForbiddenError("The file has not passed the malware scan"))
+	}
+
+	variant, ok := fileVariant(data, c.QueryParam("variant"))
+	if !ok {
+		return c.JSON(http.StatusNotFound, utils.NewNotFoundError("Variant not found"))
+	}
+
+	disposition := c.QueryParam("disposition")
+	if disposition != "inline" {
+		disposition = "attachment"
+	}
+
+	if variant.Key != "" {
+		// doc.pdf's thumb in WebP downloads as doc-thumb.webp
+		name := strings.TrimSuffix(data.Name, path.Ext(data.Name)) + "-" + variant.Name + path.Ext(variant.Key)
+		return serveObject(c, variant.Key, variant.MimeType, name, disposition)
+	}
+
+	return serveObject(c, data.Key, data.MimeType, data.Name, disposition)
+}
+
+// inlineTypes can be shown in the browser, anything else is downloaded so that
+// an uploaded HTML or SVG file cannot run scripts on the API origin
+var inlineTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+// serveObject streams a stored object, answering Range and conditional requests
+func serveObject(c echo.Context, key, contentType, name, disposition string) error {
+	object, info, err := storage.Open(c.Request().Context(), storage.Default, key)
+	if err == storage.ErrNotFound {
+		return c.JSON(http.StatusNotFound, utils.NewNotFoundError("File not found"))
+	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to open file"))
+	}
+	defer object.Close()
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	if !inlineTypes[contentType] {
+		disposition = "attachment"
+	}
+
+	header := c.Response().Header()
+	header.Set("Content-Type", contentType)
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": name}))
+	header.Set("Cache-Control", "private, no-cache")
+	if info.ETag != "" {
+		header.Set("ETag", `"`+info.ETag+`"`)
+	}
+
+	http.ServeContent(c.Response(), c.Request(), name, info.LastModified, object)
+
+	return nil
+}
+
+// canReadFile reports whether the signed in user owns the file or may read any file
+func (h *FileController) canReadFile(c echo.Context, data models.File) bool {
+	userID := c.Get("user_id").(int)
+
+	return data.OwnerID == userID || h.roles.UserHasPermission(c.Request().Context(), userID, "file.read")
+}
+
+// fileVariant finds the variant by name, an empty name means the file itself
+func fileVariant(data models.File, name string) (models.FileVariant, bool) {
+	if name == "" {
+		return models.FileVariant{}, true
+	}
+	for _, variant := range data.Variants {
+		if variant.Name == name {
+			return variant, true
+		}
+	}
+
+	return models.FileVariant{}, false
+}
+
+func downloadSignature(id int, variant, expires string) string {
+	mac := hmac.New(sha256.New, []byte("download:"+config.LoadConfig().AppKey))
+	fmt.Fprintf(mac, "%d\n%s\n%s", id, variant, expires)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func verifyDownloadSignature(id int, variant, expires, signature string) bool {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > unix {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(downloadSignature(id, variant, expires)))
+}
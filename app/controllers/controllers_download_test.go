@@ -0,0 +1,459 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"project-name/app/middlewares"
+	"project-name/app/models"
+	"project-name/app/repository"
+	"project-name/app/session"
+	"project-name/app/storage"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// fakeFiles serves file metadata from memory
+type fakeFiles struct {
+	repository.FileRepository
+	files []models.File
+}
+
+func (f *fakeFiles) GetFileByID(ctx context.Context, id int) (models.File, error) {
+	for _, data := range f.files {
+		if int(data.ID) == id {
+			return data, nil
+		}
+	}
+	return models.File{}, gorm.ErrRecordNotFound
+}
+
+func (f *fakeFiles) GetFileByKey(ctx context.Context, key string) (models.File, error) {
+	for _, data := range f.files {
+		if data.Key == key {
+			return data, nil
+		}
+		for _, variant := range data.Variants {
+			if variant.Key == key {
+				return data, nil
+			}
+		}
+	}
+	return models.File{}, gorm.ErrRecordNotFound
+}
+
+// fakeSessions keeps sessions in memory for Auth()
+type fakeSessions struct {
+	mu       sync.Mutex
+	sessions map[string]models.Session
+}
+
+func (s *fakeSessions) Save(ctx context.Context, data models.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[data.SessionID] = data
+	return nil
+}
+
+func (s *fakeSessions) Get(ctx context.Context, sessionID string) (models.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.sessions[sessionID]
+	if !ok {
+		return data, session.ErrSessionNotFound
+	}
+	return data, nil
+}
+
+func (s *fakeSessions) ListByUser(ctx context.Context, userID int) (data []models.Session, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, item := range s.sessions {
+		if item.UserID == userID {
+			data = append(data, item)
+		}
+	}
+	return
+}
+
+func (s *fakeSessions) Delete(ctx context.Context, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, sessionID)
+	return nil
+}
+
+const reportContent = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// downloadServer routes the download endpoints like router.Init, with files of
+// user 1 in local storage. User 2 is a regular user and user 3 may read any file.
+// Sign users in on the returned session store.
+func downloadServer(t *testing.T) (*echo.Echo, session.Store) {
+	t.Setenv("APP_KEY", "download-test")
+	t.Setenv("BASE_URL", "http://api.test")
+	t.Setenv("ENABLE_API_KEY", "false")
+	t.Setenv("JWT_SIGNING_METHOD", "HS256")
+	t.Setenv("JWT_SECRET", "download-test")
+	t.Setenv("JWT_ACCESS_TOKEN_TTL", "15")
+	t.Setenv("JWT_REFRESH_TOKEN_TTL", "60")
+	t.Setenv("STORAGE_URL_TTL", "300")
+	t.Setenv("FILE_SHARE_MAX_TTL", "3600")
+
+	previousStorage := storage.Default
+	t.Cleanup(func() { storage.Default = previousStorage })
+
+	local := storage.NewLocal(t.TempDir(), "http://api.test/storage", []byte("storage-test"))
+	storage.Default = local
+
+	objects := map[string]string{
+		"files/report.pdf":      reportContent,
+		"files/pending.pdf":     reportContent,
+		"files/photo.png":       "png bytes",
+		"files/photo-thumb.png": "thumb bytes",
+		"files/page.html":       "<script>alert(1)</script>",
+	}
+	for key, content := range objects {
+		if err := local.Put(context.Background(), key, strings.NewReader(content), int64(len(content)), ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	file := func(id uint, key, name, mimeType, status string, variants ...models.FileVariant) models.File {
+		data := models.File{Key: key, OwnerID: 1, Name: name, MimeType: mimeType, ScanStatus: status, Variants: variants}
+		data.ID = id
+		return data
+	}
+	files := &fakeFiles{files: []models.File{
+		file(1, "files/report.pdf", "report.pdf", "application/pdf", models.FileScanClean),
+		file(2, "files/pending.pdf", "pending.pdf", "application/pdf", models.FileScanQuarantine),
+		file(3, "files/photo.png", "photo.png", "image/png", models.FileScanClean,
+			models.FileVariant{Name: "thumb", Key: "files/photo-thumb.png", MimeType: "image/png"}),
+		file(4, "files/page.html", "page.html", "text/html", models.FileScanClean),
+	}}
+	roles := &fakeRoles{
+		users:       map[int]int{1: 3, 2: 3, 3: 2},
+		permissions: map[int][]string{2: {"file.read"}, 3: {"file.upload"}},
+	}
+
+	sessions := &fakeSessions{sessions: map[string]models.Session{}}
+
+	h := NewFileController(files, roles, sessions)
+	app := echo.New()
+	app.GET("/v1/files/:id/download", h.DownloadFile)
+	app.GET("/storage/*", h.ServeStorageObject)
+	app.POST("/v1/files/:id/share", h.ShareFile, middlewares.Auth(sessions))
+
+	return app, sessions
+}
+
+// token signs in userID with a new session
+func token(t *testing.T, sessions session.Store, userID int) string {
+	t.Helper()
+
+	data, err := session.Create(context.Background(), sessions, userID, "test", "go test", "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := models.User{RoleID: 3}
+	user.ID = uint(userID)
+	signed, err := middlewares.AuthMakeToken(user, data.SessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed
+}
+
+func request(app *echo.Echo, method, target, userToken string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	if userToken != "" {
+		req.Header.Set("Authorization", "Bearer "+userToken)
+	}
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestDownloadFileAccess(t *testing.T) {
+	app, sessions := downloadServer(t)
+	owner, other, reader := token(t, sessions, 1), token(t, sessions, 2), token(t, sessions, 3)
+
+	tests := []struct {
+		name   string
+		target string
+		token  string
+		status int
+		body   string
+	}{
+		{"owner", "/v1/files/1/download", owner, http.StatusOK, reportContent},
+		{"other user", "/v1/files/1/download", other, http.StatusNotFound, ""},
+		{"file.read", "/v1/files/1/download", reader, http.StatusOK, reportContent},
+		{"signed out", "/v1/files/1/download", "", http.StatusUnauthorized, ""},
+		{"forged token", "/v1/files/1/download", owner[:len(owner)-2] + "xx", http.StatusUnauthorized, ""},
+		{"variant", "/v1/files/3/download?variant=thumb", owner, http.StatusOK, "thumb bytes"},
+		{"unknown variant", "/v1/files/3/download?variant=huge", owner, http.StatusNotFound, ""},
+		{"not scanned yet", "/v1/files/2/download", owner, http.StatusForbidden, ""},
+		{"unknown file", "/v1/files/99/download", reader, http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := request(app, http.MethodGet, tt.target, tt.token, nil)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.body != "" && rec.Body.String() != tt.body {
+				t.Errorf("body = %q, want %q", rec.Body, tt.body)
+			}
+		})
+	}
+}
+
+func TestDownloadFileHeaders(t *testing.T) {
+	app, sessions := downloadServer(t)
+	owner := token(t, sessions, 1)
+
+	tests := []struct {
+		name        string
+		target      string
+		contentType string
+		disposition string
+	}{
+		{"attachment by default", "/v1/files/1/download", "application/pdf", `attachment; filename=report.pdf`},
+		{"inline only for images", "/v1/files/1/download?disposition=inline", "application/pdf", `attachment; filename=report.pdf`},
+		{"inline image", "/v1/files/3/download?disposition=inline", "image/png", `inline; filename=photo.png`},
+		{"variant name", "/v1/files/3/download?variant=thumb", "image/png", `attachment; filename=photo-thumb.png`},
+		{"html never inline", "/v1/files/4/download?disposition=inline", "text/html", `attachment; filename=page.html`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := request(app, http.MethodGet, tt.target, owner, nil)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+			}
+
+			header := rec.Header()
+			if header.Get("Content-Type") != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", header.Get("Content-Type"), tt.contentType)
+			}
+			if header.Get("Content-Disposition") != tt.disposition {
+				t.Errorf("Content-Disposition = %q, want %q", header.Get("Content-Disposition"), tt.disposition)
+			}
+			if header.Get("X-Content-Type-Options") != "nosniff" {
+				t.Errorf("X-Content-Type-Options = %q", header.Get("X-Content-Type-Options"))
+			}
+		})
+	}
+}
+
+func TestDownloadFileRange(t *testing.T) {
+	app, sessions := downloadServer(t)
+	owner := token(t, sessions, 1)
+	size := strconv.Itoa(len(reportContent))
+
+	full := request(app, http.MethodGet, "/v1/files/1/download", owner, nil)
+	etag := full.Header().Get("ETag")
+	if full.Header().Get("Accept-Ranges") != "bytes" || etag == "" {
+		t.Fatalf("Accept-Ranges = %q, ETag = %q", full.Header().Get("Accept-Ranges"), etag)
+	}
+
+	tests := []struct {
+		name         string
+		header       map[string]string
+		status       int
+		contentRange string
+		body         string
+	}{
+		{"first bytes", map[string]string{"Range": "bytes=0-3"}, http.StatusPartialContent, "bytes 0-3/" + size, "0123"},
+		{"middle", map[string]string{"Range": "bytes=10-15"}, http.StatusPartialContent, "bytes 10-15/" + size, "abcdef"},
+		{"open ended", map[string]string{"Range": "bytes=30-"}, http.StatusPartialContent, "bytes 30-35/" + size, "uvwxyz"},
+		{"suffix", map[string]string{"Range": "bytes=-4"}, http.StatusPartialContent, "bytes 32-35/" + size, "wxyz"},
+		{"past the end", map[string]string{"Range": "bytes=100-"}, http.StatusRequestedRangeNotSatisfiable, "bytes */" + size, ""},
+		{"resume with a matching etag", map[string]string{"Range": "bytes=30-", "If-Range": etag}, http.StatusPartialContent, "bytes 30-35/" + size, "uvwxyz"},
+		{"changed since", map[string]string{"Range": "bytes=30-", "If-Range": `"stale"`}, http.StatusOK, "", reportContent},
+		{"not modified", map[string]string{"If-None-Match": etag}, http.StatusNotModified, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := request(app, http.MethodGet, "/v1/files/1/download", owner, tt.header)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if got := rec.Header().Get("Content-Range"); got != tt.contentRange {
+				t.Errorf("Content-Range = %q, want %q", got, tt.contentRange)
+			}
+			if tt.body != "" && rec.Body.String() != tt.body {
+				t.Errorf("body = %q, want %q", rec.Body, tt.body)
+			}
+		})
+	}
+}
+
+// share creates a signed link as the user and returns its path and query
+func share(t *testing.T, app *echo.Echo, id int, userToken, body string) (*httptest.ResponseRecorder, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/files/"+strconv.Itoa(id)+"/share", strings.NewReader(body))
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("Authorization", "Bearer "+userToken)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	var resp struct {
+		Data struct {
+			URL string `json:"url"`
+		} `json:"data"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp.Data.URL == "" {
+		return rec, ""
+	}
+
+	link, err := url.Parse(resp.Data.URL)
+	if err != nil || link.Host != "api.test" {
+		t.Fatalf("url = %q, %v", resp.Data.URL, err)
+	}
+
+	return rec, link.RequestURI()
+}
+
+func TestShareFile(t *testing.T) {
+	app, sessions := downloadServer(t)
+	owner, other, reader := token(t, sessions, 1), token(t, sessions, 2), token(t, sessions, 3)
+
+	if rec, link := share(t, app, 1, other, `{}`); rec.Code != http.StatusNotFound || link != "" {
+		t.Errorf("other user: status = %d, link = %q", rec.Code, link)
+	}
+	if rec, _ := share(t, app, 1, owner, `{"expires_in": 7200}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("over FILE_SHARE_MAX_TTL: status = %d", rec.Code)
+	}
+	if rec, _ := share(t, app, 3, owner, `{"variant": "huge"}`); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown variant: status = %d", rec.Code)
+	}
+	if _, link := share(t, app, 1, reader, `{}`); link == "" {
+		t.Error("file.read cannot share")
+	}
+
+	_, link := share(t, app, 1, owner, `{"expires_in": 120}`)
+	rec := request(app, http.MethodGet, link, "", map[string]string{"Range": "bytes=0-3"})
+	if rec.Code != http.StatusPartialContent || rec.Body.String() != "0123" {
+		t.Fatalf("signed link: status = %d, body = %q", rec.Code, rec.Body)
+	}
+
+	_, thumb := share(t, app, 3, owner, `{"variant": "thumb"}`)
+	if rec := request(app, http.MethodGet, thumb, "", nil); rec.Body.String() != "thumb bytes" {
+		t.Errorf("variant link: status = %d, body = %q", rec.Code, rec.Body)
+	}
+}
+
+func TestDownloadFileSignedLinks(t *testing.T) {
+	app, _ := downloadServer(t)
+
+	expires := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+	signed := func(id int, variant, expires string) url.Values {
+		return url.Values{"variant": {variant}, "expires": {expires}, "signature": {downloadSignature(id, variant, expires)}}
+	}
+	link := func(id int, query url.Values) string {
+		return "/v1/files/" + strconv.Itoa(id) + "/download?" + query.Encode()
+	}
+
+	tampered := signed(1, "", expires)
+	tampered.Set("signature", strings.Repeat("0", 64))
+
+	extended := signed(1, "", expires)
+	extended.Set("expires", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+
+	otherVariant := signed(3, "thumb", expires)
+	otherVariant.Del("variant")
+
+	past := strconv.FormatInt(time.Now().Add(-time.Second).Unix(), 10)
+
+	tests := []struct {
+		name   string
+		target string
+		status int
+	}{
+		{"valid", link(1, signed(1, "", expires)), http.StatusOK},
+		{"valid variant", link(3, signed(3, "thumb", expires)), http.StatusOK},
+		{"expired", link(1, signed(1, "", past)), http.StatusForbidden},
+		{"tampered signature", link(1, tampered), http.StatusForbidden},
+		{"extended expiry", link(1, extended), http.StatusForbidden},
+		{"other file", link(4, signed(1, "", expires)), http.StatusForbidden},
+		{"original instead of the variant", link(3, otherVariant), http.StatusForbidden},
+		{"not a number", link(1, url.Values{"expires": {"soon"}, "signature": {downloadSignature(1, "", "soon")}}), http.StatusForbidden},
+		{"not scanned yet", link(2, signed(2, "", expires)), http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := request(app, http.MethodGet, tt.target, "", nil)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+		})
+	}
+}
+
+func TestServeStorageObject(t *testing.T) {
+	app, _ := downloadServer(t)
+	local := storage.Default.(*storage.Local)
+
+	signed := func(key string, ttl time.Duration) string {
+		raw, err := local.SignedURL(context.Background(), key, ttl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, _ := url.Parse(raw)
+		return u.RequestURI()
+	}
+
+	tests := []struct {
+		name        string
+		target      string
+		status      int
+		contentType string
+		disposition string
+	}{
+		{"image inline", signed("files/photo.png", time.Minute), http.StatusOK, "image/png", "inline; filename=photo.png"},
+		{"variant", signed("files/photo-thumb.png", time.Minute), http.StatusOK, "image/png", "inline; filename=photo-thumb.png"},
+		{"html as attachment", signed("files/page.html", time.Minute), http.StatusOK, "text/html", "attachment; filename=page.html"},
+		{"not scanned yet", signed("files/pending.pdf", time.Minute), http.StatusForbidden, "", ""},
+		{"expired", signed("files/photo.png", -time.Minute), http.StatusForbidden, "", ""},
+		{"other key", strings.Replace(signed("files/photo.png", time.Minute), "photo.png", "report.pdf", 1), http.StatusForbidden, "", ""},
+		{"unsigned", "/storage/files/photo.png", http.StatusForbidden, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := request(app, http.MethodGet, tt.target, "", nil)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+			if got := rec.Header().Get("Content-Disposition"); got != tt.disposition {
+				t.Errorf("Content-Disposition = %q, want %q", got, tt.disposition)
+			}
+			if rec.Header().Get("X-Content-Type-Options") != "nosniff" {
+				t.Error("nosniff is missing")
+			}
+		})
+	}
+}
@@ -1,21 +1,50 @@
 package controllers
 
 import (
+	"bytes"
+	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"math/big"
+	"mime/multipart"
 	"net/http"
 	"os"
-	"project-name/config"
+	"path"
+	"project-name/app/imaging"
+	"project-name/app/models"
+	"project-name/app/repository"
+	"project-name/app/reqres"
+	"project-name/app/scanner"
+	"project-name/app/session"
+	"project-name/app/storage"
+	"project-name/app/utils"
+	"strconv"
 	"strings"
 
+	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
+// FileController serves the /v1/file endpoints
+type FileController struct {
+	files    repository.FileRepository
+	roles    repository.RoleRepository
+	sessions session.Store
+}
+
+func NewFileController(files repository.FileRepository, roles repository.RoleRepository, sessions session.Store) *FileController {
+	return &FileController{files: files, roles: roles, sessions: sessions}
+}
+
 // UploadFile godoc
 // @Summary Upload File
-// @Description Upload File
+// @Description Upload a file owned by the logged in user. JPEG and PNG images are turned upright, stripped of metadata and resized into the configured variants. Set its key on a model, e.g. the user image, to keep it: files nothing refers to are deleted after a grace period. Files the malware scanner flags are rejected with 422, 503 means the scanner could not be reached.
 // @Tags File
 // @Accept multipart/form-data
 // @Produce application/json
@@ -23,77 +52,46 @@ import (
 // @Success 200
 // @Router /v1/file/upload [post]
 // @Security JwtToken
-func UploadFile(c echo.Context) error {
+func (h *FileController) UploadFile(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Get("user_id").(int)
+
 	// Read form file
 	file, err := c.FormFile("file")
 	if err != nil {
 		return err
 	}
-	// Source
-	src, err := file.Open()
-	if err != nil {
-		return err
-	}
-	defer src.Close()
 
-	// Check file type
-	allowedTypes := map[string]bool{
-		"application/pdf": true,
-		"image/jpeg":      true,
-		"image/jpg":       true,
-		"image/png":       true,
-	}
-
-	// Get file header to check MIME type
-	buffer := make([]byte, 512)
-	_, err = src.Read(buffer)
-	if err != nil {
-		return err
-	}
-	src.Seek(0, io.SeekStart) // Reset the read pointer to the start of the file
-
-	mimeType := http.DetectContentType(buffer)
-	if !allowedTypes[mimeType] {
+	data, err := saveUploadedFile(ctx, h.files, userID, file, uploadAllowedTypes)
+	if err == errFileTypeNotAllowed {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{
 			"status":  400,
 			"message": "File type not allowed. Only PDF, JPEG, JPG, and PNG are accepted.",
 		})
 	}
-
-	// Create directory path for uploads if not exists
-	uploadDir := config.LoadConfig().DirPath
-	if _, err := os.Stat(uploadDir); os.IsNotExist(err) {
-		os.MkdirAll(uploadDir, os.ModePerm)
+	if errors.Is(err, errInvalidImage) {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  400,
+			"message": "The image is corrupt or too large",
+		})
 	}
-
-	cleanFilename := strings.ReplaceAll(file.Filename, " ", "")
-
-	randomString, _ := GenerateRandomString(10)
-
-	filename := randomString + "_" + cleanFilename
-
-	// Destination
-	dst, err := os.Create(fmt.Sprintf("%s/%s", uploadDir, filename))
-	if err != nil {
-		return err
+	if scanErr := scanErrorResponse(file.Filename, err); scanErr != nil {
+		return c.JSON(scanErr.Status(), scanErr)
 	}
-	defer dst.Close()
-
-	// Copy
-	if _, err = io.Copy(dst, src); err != nil {
+	if err != nil {
 		return err
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"status":  200,
 		"message": "Upload Success",
-		"data":    filename,
+		"data":    repository.BuildFileResponse(data),
 	})
 }
 
 // UploadMultipleFiles godoc
 // @Summary Upload Multiple Files
-// @Description Upload Multiple Files
+// @Description Upload files owned by the logged in user, see Upload File
 // @Tags File
 // @Accept multipart/form-data
 // @Produce application/json
@@ -101,7 +99,10 @@ func UploadFile(c echo.Context) error {
 // @Success 200
 // @Router /v1/file/upload-multiple [post]
 // @Security JwtToken
-func UploadMultipleFiles(c echo.Context) error {
+func (h *FileController) UploadMultipleFiles(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Get("user_id").(int)
+
 	// Parse multipart form
 	form, err := c.MultipartForm()
 	if err != nil {
@@ -119,60 +120,30 @@ func UploadMultipleFiles(c echo.Context) error {
 		})
 	}
 
-	// Allowed file types
-	allowedTypes := map[string]bool{
-		"application/pdf": true,
-		"image/jpeg":      true,
-		"image/jpg":       true,
-		"image/png":       true,
-	}
-
-	uploadDir := config.LoadConfig().DirPath
-	if _, err := os.Stat(uploadDir); os.IsNotExist(err) {
-		os.MkdirAll(uploadDir, os.ModePerm)
-	}
-
-	uploadedFiles := []string{}
+	uploadedFiles := []reqres.FileResponse{}
 
 	for _, file := range files {
-		src, err := file.Open()
-		if err != nil {
-			return err
-		}
-		defer src.Close()
-
-		// Check file type
-		buffer := make([]byte, 512)
-		_, err = src.Read(buffer)
-		if err != nil {
-			return err
-		}
-		src.Seek(0, io.SeekStart)
-
-		mimeType := http.DetectContentType(buffer)
-		if !allowedTypes[mimeType] {
+		data, err := saveUploadedFile(ctx, h.files, userID, file, uploadAllowedTypes)
+		if err == errFileTypeNotAllowed {
 			return c.JSON(http.StatusBadRequest, map[string]interface{}{
 				"status":  400,
 				"message": fmt.Sprintf("File %s has an invalid type", file.Filename),
 			})
 		}
-
-		cleanFilename := strings.ReplaceAll(file.Filename, " ", "")
-		randomString, _ := GenerateRandomString(10)
-		filename := randomString + "_" + cleanFilename
-
-		dst, err := os.Create(fmt.Sprintf("%s/%s", uploadDir, filename))
-		if err != nil {
-			return err
+		if errors.Is(err, errInvalidImage) {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"status":  400,
+				"message": fmt.Sprintf("Image %s is corrupt or too large", file.Filename),
+			})
 		}
-		defer dst.Close()
-
-		// Copy file to destination
-		if _, err := io.Copy(dst, src); err != nil {
+		if scanErr := scanErrorResponse(file.Filename, err); scanErr != nil {
+			return c.JSON(scanErr.Status(), scanErr)
+		}
+		if err != nil {
 			return err
 		}
 
-		uploadedFiles = append(uploadedFiles, filename)
+		uploadedFiles = append(uploadedFiles, repository.BuildFileResponse(data))
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -182,6 +153,285 @@ func UploadMultipleFiles(c echo.Context) error {
 	})
 }
 
+// GetMyFiles godoc
+// @Summary Get My Files
+// @Description List the files uploaded by the logged in user
+// @Tags File
+// @Accept  json
+// @Produce  json
+// @Success 200
+// @Param page query int false "Page"
+// @Param limit query int false "Limit"
+// @Param search query string false "Search"
+// @Param sort query string false "Sort"
+// @Param order query string false "Order"
+// @Param filter[field][op] query string false "Filter, e.g. filter[mime_type]=image/png, filter[ref_count]=0"
+// @Param cursor query string false "Keyset cursor from next_cursor or prev_cursor, send it empty for the first page"
+// @Param count query bool false "Count the total, defaults to true with offset paging and false with a cursor"
+// @Router /v1/file [get]
+// @Security JwtToken
+func (h *FileController) GetMyFiles(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Get("user_id").(int)
+	param := utils.PopulatePaging(c, "")
+
+	data, err := h.files.GetFiles(ctx, userID, param)
+	if errVal, ok := err.(validation.Errors); ok {
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
+	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to get files"))
+	}
+
+	return c.JSON(200, data)
+}
+
+// DeleteMyFile godoc
+// @Summary Delete My File
+// @Description Delete a file uploaded by the logged in user. Files still in use are refused with 409.
+// @Tags File
+// @Accept  json
+// @Produce  json
+// @Param id path int true "File ID"
+// @Success 200
+// @Router /v1/file/{id} [delete]
+// @Security JwtToken
+func (h *FileController) DeleteMyFile(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Get("user_id").(int)
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	data, err := h.files.GetFileByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && data.OwnerID != userID) {
+		return c.JSON(http.StatusNotFound, utils.NewNotFoundError("File not found"))
+	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to get file"))
+	}
+
+	err = h.files.DeleteFile(ctx, data)
+	if err == repository.ErrFileInUse {
+		return c.JSON(http.StatusConflict, utils.NewHttpError(http.StatusConflict, err.Error(), "Remove the file from where it is used first"))
+	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to delete file"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    repository.BuildFileResponse(data),
+		"message": "Delete File Success",
+	})
+}
+
+// Allowed file types
+var uploadAllowedTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/jpg":       true,
+	"image/png":       true,
+}
+
+var (
+	errFileTypeNotAllowed = errors.New("file type not allowed")
+	errInvalidImage       = errors.New("invalid image")
+	errScanFailed         = errors.New("file could not be scanned")
+)
+
+// fileInfectedError rejects an upload the scanner found Threat in
+type fileInfectedError struct {
+	Threat string
+}
+
+func (e *fileInfectedError) Error() string {
+	return "file is infected"
+}
+
+// scanErrorResponse answers an upload the scanner rejected, nil for any other error
+func scanErrorResponse(name string, err error) utils.HttpErr {
+	var infected *fileInfectedError
+	if errors.As(err, &infected) {
+		return utils.NewHttpError(http.StatusUnprocessableEntity, err.Error(), fmt.Sprintf("%s contains malware (%s) and was rejected", name, infected.Threat))
+	}
+	if errors.Is(err, errScanFailed) {
+		return utils.NewHttpError(http.StatusServiceUnavailable, errScanFailed.Error(), fmt.Sprintf("%s could not be scanned for malware, please try again later", name))
+	}
+
+	return nil
+}
+
+// saveUploadedFile stores a multipart file, see saveUpload
+func saveUploadedFile(ctx context.Context, files repository.FileRepository, ownerID int, file *multipart.FileHeader, allowedTypes map[string]bool) (data models.File, err error) {
+	// Source
+	src, err := file.Open()
+	if err != nil {
+		return
+	}
+	defer src.Close()
+
+	return saveUpload(ctx, files, ownerID, file.Filename, src, allowedTypes)
+}
+
+// saveUpload checks the MIME type from the file header, stores the file in the
+// storage backend under a random prefix and records it as owned by ownerID. The
+// record is quarantined while the scanner checks the upload, nothing reaches the
+// storage backend before it passes.
+func saveUpload(ctx context.Context, files repository.FileRepository, ownerID int, name string, src io.Reader, allowedTypes map[string]bool) (data models.File, err error) {
+	// Get file header to check MIME type
+	buffer := make([]byte, 512)
+	n, err := io.ReadFull(src, buffer)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return
+	}
+	buffer = buffer[:n]
+	src = io.MultiReader(bytes.NewReader(buffer), src) // Put the header back in front of the rest
+
+	mimeType := http.DetectContentType(buffer)
+	if !allowedTypes[mimeType] {
+		err = errFileTypeNotAllowed
+		return
+	}
+
+	cleanFilename := strings.ReplaceAll(name, " ", "")
+	cleanFilename = strings.NewReplacer("/", "", "\\", "").Replace(cleanFilename)
+
+	randomString, _ := GenerateRandomString(10)
+
+	filename := randomString + "_" + cleanFilename
+
+	// the upload waits for the verdict in a temporary file
+	spool, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(spool, io.TeeReader(src, hash))
+	if err != nil {
+		return
+	}
+
+	data, err = files.CreateFile(ctx, models.File{
+		Key:        filename,
+		OwnerID:    ownerID,
+		Name:       name,
+		Size:       size,
+		MimeType:   mimeType,
+		SHA256:     hex.EncodeToString(hash.Sum(nil)),
+		ScanStatus: models.FileScanQuarantine,
+	})
+	if err != nil {
+		return
+	}
+
+	if err = scanUpload(ctx, files, data, spool); err != nil {
+		return
+	}
+
+	record := data
+	if _, err = spool.Seek(0, io.SeekStart); err == nil {
+		if imaging.Default != nil && imaging.Supported(mimeType) {
+			record, err = saveImage(ctx, record, spool)
+		} else {
+			err = storage.Default.Put(ctx, record.Key, spool, record.Size, mimeType)
+		}
+	}
+	if err != nil {
+		discardFile(ctx, files, data)
+		return
+	}
+
+	record.ScanStatus = models.FileScanClean
+	data, err = files.UpdateFile(ctx, record)
+	if err != nil {
+		deleteStoredObjects(ctx, record)
+		discardFile(ctx, files, data)
+	}
+
+	return
+}
+
+// scanUpload runs the scanner over the spooled upload of a quarantined file. An
+// infected file stays as a record without objects until the sweeper removes it,
+// a file the scanner gave no verdict on is dropped.
+func scanUpload(ctx context.Context, files repository.FileRepository, data models.File, spool io.ReadSeeker) error {
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		discardFile(ctx, files, data)
+		return err
+	}
+
+	result, err := scanner.Default.Scan(ctx, spool)
+	if err != nil {
+		discardFile(ctx, files, data)
+		return fmt.Errorf("%w: %v", errScanFailed, err)
+	}
+
+	if result.Infected {
+		if _, err := files.UpdateScanStatus(ctx, data, models.FileScanInfected, result.Threat); err != nil {
+			log.Printf("Failed to mark file %d as infected with %s. Error: %v", data.ID, result.Threat, err)
+		}
+		return &fileInfectedError{Threat: result.Threat}
+	}
+
+	return nil
+}
+
+// discardFile removes the record of an upload that could not be stored
+func discardFile(ctx context.Context, files repository.FileRepository, data models.File) {
+	if err := files.DeleteFile(ctx, data); err != nil {
+		log.Printf("Failed to delete file %d. Error: %v", data.ID, err)
+	}
+}
+
+// saveImage stores the upright, metadata free image and its variants. Size and
+// hash describe the stored image, not the upload.
+func saveImage(ctx context.Context, data models.File, src io.Reader) (models.File, error) {
+	upload, err := io.ReadAll(src)
+	if err != nil {
+		return data, err
+	}
+
+	original, variants, err := imaging.Default.Process(upload)
+	if err != nil {
+		return data, fmt.Errorf("%w: %v", errInvalidImage, err)
+	}
+
+	hash := sha256.Sum256(original.Data)
+	data.Size, data.SHA256 = int64(len(original.Data)), hex.EncodeToString(hash[:])
+
+	base := strings.TrimSuffix(data.Key, path.Ext(data.Key))
+	for _, variant := range variants {
+		data.Variants = append(data.Variants, models.FileVariant{
+			Name:     variant.Name,
+			Key:      "variants/" + variant.Name + "/" + base + variant.Ext,
+			Width:    variant.Width,
+			Height:   variant.Height,
+			Size:     int64(len(variant.Data)),
+			MimeType: variant.MimeType,
+		})
+	}
+
+	err = storage.Default.Put(ctx, data.Key, bytes.NewReader(original.Data), data.Size, original.MimeType)
+	for i := 0; err == nil && i < len(variants); i++ {
+		err = storage.Default.Put(ctx, data.Variants[i].Key, bytes.NewReader(variants[i].Data), data.Variants[i].Size, variants[i].MimeType)
+	}
+	if err != nil {
+		deleteStoredObjects(ctx, data)
+	}
+
+	return data, err
+}
+
+// deleteStoredObjects cleans up after an upload that could not be recorded
+func deleteStoredObjects(ctx context.Context, data models.File) {
+	for _, variant := range data.Variants {
+		storage.Default.Delete(ctx, variant.Key)
+	}
+	storage.Default.Delete(ctx, data.Key)
+}
+
 func GenerateRandomString(length int) (string, error) {
 	const chars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	result := make([]byte, length)
@@ -194,15 +444,3 @@ func GenerateRandomString(length int) (string, error) {
 	}
 	return string(result), nil
 }
-
-func DeleteFile(filename string) error {
-
-	e := os.Remove(fmt.Sprintf(config.LoadConfig().DirPath+"%s", filename))
-	if e != nil {
-		fmt.Println("Failed to delete file. Error:", e)
-	}
-
-	fmt.Println("Success to delete file.")
-
-	return nil
-}
@@ -0,0 +1,265 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"project-name/app/middlewares"
+	"project-name/app/repository"
+	"project-name/app/reqres"
+	"project-name/app/utils"
+	"strconv"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+)
+
+// MeController serves the /v1/me endpoints of the logged in user
+type MeController struct {
+	users   repository.UserRepository
+	auth    repository.AuthRepository
+	regions repository.RegionRepository
+	files   repository.FileRepository
+}
+
+func NewMeController(users repository.UserRepository, auth repository.AuthRepository, regions repository.RegionRepository, files repository.FileRepository) *MeController {
+	return &MeController{users: users, auth: auth, regions: regions, files: files}
+}
+
+// Only images are accepted as avatar
+var avatarAllowedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/jpg":  true,
+	"image/png":  true,
+}
+
+// GetMe godoc
+// @Summary Get Me
+// @Description Get the profile of the logged in user
+// @Tags Me
+// @Accept  json
+// @Produce  json
+// @Param fields query string false "Comma separated fields to return, e.g. id,name,email"
+// @Param expand query string false "Comma separated relations to load: role, province, city, subdistrict"
+// @Success 200
+// @Router /v1/me [get]
+// @Security JwtToken
+func (h *MeController) GetMe(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Get("user_id").(int)
+
+	data, err := h.users.GetUserProjection(ctx, userID, utils.PopulateProjection(c))
+	if errVal, ok := err.(validation.Errors); ok {
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
+	}
+	if err != nil {
+		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    data,
+		"message": "Get Me Success",
+	})
+}
+
+// UpdateMe godoc
+// @Summary Update Me
+// @Description Update the profile of the logged in user. Email, role and verification cannot be changed here. Sending any of prov, kab and kec replaces the whole address, which must follow the province, city, subdistrict hierarchy.
+// @Tags Me
+// @Accept  json
+// @Produce  json
+// @Param request body reqres.MeUpdateRequest true "Update Me Request"
+// @Success 200
+// @Router /v1/me [patch]
+// @Security JwtToken
+func (h *MeController) UpdateMe(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Get("user_id").(int)
+
+	data, err := h.users.GetUserByIDPlain(ctx, userID)
+	if err != nil {
+		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
+	}
+
+	var req reqres.MeUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(400, utils.NewUnprocessableEntityError(err.Error()))
+	}
+
+	err = req.Validate(ctx, h.regions)
+	if errVal, ok := err.(validation.Errors); ok {
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
+	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to validate user"))
+	}
+
+	if req.Name != "" {
+		data.Name = req.Name
+	}
+	if req.Gender != "" {
+		data.Gender = req.Gender
+	}
+	if req.TglLahir != "" {
+		tglLahir, err := time.Parse("2006-01-02 15:04:05", req.TglLahir)
+		if err != nil {
+			tglLahir, err = time.Parse("2006-01-02", req.TglLahir)
+			if err != nil {
+				return c.JSON(400, utils.Respond(400, err, "Invalid Tanggal Lahir format"))
+			}
+		}
+
+		data.TglLahir = tglLahir
+	}
+	if req.Phone != "" {
+		phone, _ := h.users.GetUserByPhone(ctx, req.Phone)
+		if phone.Phone != "" && phone.ID != data.ID {
+			return c.JSON(400, utils.NewBadRequestError("Phone already exists"))
+		}
+		data.Phone = req.Phone
+	}
+	if req.Address != "" {
+		data.Address = req.Address
+	}
+	if req.Prov != 0 || req.Kab != 0 || req.Kec != 0 {
+		data.Prov, data.Kab, data.Kec = req.Prov, req.Kab, req.Kec
+	}
+	if req.Kel != "" {
+		data.Kel = req.Kel
+	}
+	if req.PostalCode != "" {
+		data.PostalCode = req.PostalCode
+	}
+
+	update, err := h.users.UpdateUser(ctx, data, userID)
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to update user"))
+	}
+
+	response, err := h.users.GetUserByID(ctx, int(update.ID))
+	if err != nil {
+		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    response,
+		"message": "Update Me Success",
+	})
+}
+
+// ChangeMyEmail godoc
+// @Summary Change My Email
+// @Description Send a verification link to the new email. The email is changed once the link is followed.
+// @Tags Me
+// @Accept  json
+// @Produce  json
+// @Param request body reqres.ChangeEmailRequest true "Change Email Request"
+// @Success 200
+// @Router /v1/me/email [post]
+// @Security JwtToken
+func (h *MeController) ChangeMyEmail(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Get("user_id").(int)
+
+	var req reqres.ChangeEmailRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(400, utils.NewUnprocessableEntityError(err.Error()))
+	}
+
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
+	}
+
+	user, err := h.users.GetUserByIDPlain(ctx, userID)
+	if err != nil {
+		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
+	}
+
+	if err := middlewares.VerifyPassword(req.Password, user.Password); err != nil {
+		return c.JSON(400, utils.NewBadRequestError("Wrong password"))
+	}
+
+	if req.Email == user.Email {
+		return c.JSON(400, utils.NewBadRequestError("New email is the same as the current email"))
+	}
+
+	existing, _ := h.users.GetUserByEmail(ctx, req.Email)
+	if existing.Email != "" {
+		return c.JSON(400, utils.NewBadRequestError("Email already exists"))
+	}
+
+	retryAfter, err := h.auth.RequestEmailChange(ctx, user, req.Email)
+	if errors.Is(err, utils.ErrTooManyRequests) {
+		c.Response().Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+		return c.JSON(http.StatusTooManyRequests, utils.NewTooManyRequestsError("Please wait before requesting another verification email"))
+	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to create email change request"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  200,
+		"message": "Verification link sent to the new email",
+	})
+}
+
+// UploadMyAvatar godoc
+// @Summary Upload My Avatar
+// @Description Replace the avatar of the logged in user. Only JPEG and PNG are accepted.
+// @Tags Me
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param file formData file true "Avatar image"
+// @Success 200
+// @Router /v1/me/avatar [post]
+// @Security JwtToken
+func (h *MeController) UploadMyAvatar(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Get("user_id").(int)
+
+	data, err := h.users.GetUserByIDPlain(ctx, userID)
+	if err != nil {
+		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(400, utils.NewBadRequestError("File is required"))
+	}
+
+	avatar, err := saveUploadedFile(ctx, h.files, userID, file, avatarAllowedTypes)
+	if err == errFileTypeNotAllowed {
+		return c.JSON(400, utils.NewBadRequestError("File type not allowed. Only JPEG, JPG, and PNG are accepted."))
+	}
+	if errors.Is(err, errInvalidImage) {
+		return c.JSON(400, utils.NewBadRequestError("The image is corrupt or too large"))
+	}
+	if scanErr := scanErrorResponse(file.Filename, err); scanErr != nil {
+		return c.JSON(scanErr.Status(), scanErr)
+	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to upload avatar"))
+	}
+
+	// the previous avatar is released here and swept once its grace period is over
+	data.Image = avatar.Key
+
+	update, err := h.users.UpdateUser(ctx, data, userID)
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to update user"))
+	}
+
+	response, err := h.users.GetUserByID(ctx, int(update.ID))
+	if err != nil {
+		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    response,
+		"message": "Upload Avatar Success",
+	})
+}
@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"net/http"
+	"project-name/app/repository"
+	"project-name/app/utils"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+)
+
+// OutboxController serves the /v1/outbox endpoints
+type OutboxController struct {
+	outbox repository.OutboxRepository
+}
+
+func NewOutboxController(outbox repository.OutboxRepository) *OutboxController {
+	return &OutboxController{outbox: outbox}
+}
+
+// GetOutboxMessages godoc
+// @Summary Get Outbox Messages
+// @Description Get Outbox Messages
+// @Tags Outbox
+// @Accept  json
+// @Produce  json
+// @Success 200
+// @Param status query string false "Status (pending, processing, sent, dead)"
+// @Param page query int false "Page"
+// @Param limit query int false "Limit"
+// @Param search query string false "Search"
+// @Param sort query string false "Sort"
+// @Param order query string false "Order"
+// @Param filter[field][op] query string false "Filter, e.g. filter[attempts][gte]=3"
+// @Param cursor query string false "Keyset cursor from next_cursor or prev_cursor, send it empty for the first page"
+// @Param count query bool false "Count the total, defaults to true with offset paging and false with a cursor"
+// @Router /v1/outbox [get]
+// @Security JwtToken
+func (h *OutboxController) GetOutboxMessages(c echo.Context) error {
+	ctx := c.Request().Context()
+	param := utils.PopulatePaging(c, "status")
+
+	status, _ := param.Custom.(string)
+	data, err := h.outbox.GetOutboxMessages(ctx, status, param)
+	if errVal, ok := err.(validation.Errors); ok {
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
+	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to get outbox messages"))
+	}
+
+	return c.JSON(200, data)
+}
+
+// GetOutboxMessageByID godoc
+// @Summary Get Outbox Message By ID
+// @Description Get Outbox Message By ID
+// @Tags Outbox
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Outbox Message ID"
+// @Success 200
+// @Router /v1/outbox/{id} [get]
+// @Security JwtToken
+func (h *OutboxController) GetOutboxMessageByID(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	data, err := h.outbox.GetOutboxMessageByID(ctx, id)
+	if err != nil {
+		return c.JSON(400, utils.Respond(400, err, "Failed to get outbox message"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    repository.BuildOutboxMessageResponse(data),
+		"message": "Get Outbox Message Success",
+	})
+}
+
+// RetryOutboxMessage godoc
+// @Summary Retry Outbox Message
+// @Description Queue a dead or pending message again with a fresh attempt budget
+// @Tags Outbox
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Outbox Message ID"
+// @Success 200
+// @Router /v1/outbox/{id}/retry [post]
+// @Security JwtToken
+func (h *OutboxController) RetryOutboxMessage(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	data, err := h.outbox.GetOutboxMessageByID(ctx, id)
+	if err != nil {
+		return c.JSON(400, utils.Respond(400, err, "Failed to get outbox message"))
+	}
+
+	update, err := h.outbox.RetryOutboxMessage(ctx, data)
+	if err != nil {
+		return c.JSON(400, utils.NewBadRequestError(err.Error()))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    repository.BuildOutboxMessageResponse(update),
+		"message": "Retry Outbox Message Success",
+	})
+}
+
+// RetryDeadOutboxMessages godoc
+// @Summary Retry Dead Outbox Messages
+// @Description Queue every dead-lettered message again
+// @Tags Outbox
+// @Accept  json
+// @Produce  json
+// @Success 200
+// @Router /v1/outbox/retry-dead [post]
+// @Security JwtToken
+func (h *OutboxController) RetryDeadOutboxMessages(c echo.Context) error {
+	ctx := c.Request().Context()
+	count, err := h.outbox.RetryDeadOutboxMessages(ctx)
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to retry outbox messages"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    count,
+		"message": "Retry Dead Outbox Messages Success",
+	})
+}
@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"project-name/app/repository"
+	"project-name/app/reqres"
+	"project-name/app/utils"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// RegionController serves the /v1/region endpoints. Region data is static, the
+// router lets clients cache these responses.
+type RegionController struct {
+	regions repository.RegionRepository
+}
+
+func NewRegionController(regions repository.RegionRepository) *RegionController {
+	return &RegionController{regions: regions}
+}
+
+// GetProvinces godoc
+// @Summary Get Provinces
+// @Description List every province, or the ones matching search
+// @Tags Region
+// @Accept  json
+// @Produce  json
+// @Param search query string false "Name prefix, typos are tolerated"
+// @Success 200
+// @Router /v1/region/province [get]
+func (h *RegionController) GetProvinces(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	data, err := h.regions.GetProvinces(ctx, c.QueryParam("search"))
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to get provinces"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    data,
+		"message": "Get Provinces Success",
+	})
+}
+
+// GetCities godoc
+// @Summary Get Cities
+// @Description List the cities of a province, or the ones matching search
+// @Tags Region
+// @Accept  json
+// @Produce  json
+// @Param prov_id path int true "Province ID"
+// @Param search query string false "Name prefix, typos are tolerated"
+// @Success 200
+// @Router /v1/region/city/{prov_id} [get]
+func (h *RegionController) GetCities(c echo.Context) error {
+	ctx := c.Request().Context()
+	provinceID, _ := strconv.Atoi(c.Param("prov_id"))
+
+	if _, err := h.regions.GetProvinceByID(ctx, provinceID); err != nil {
+		return regionLookupError(c, err, "Province not found")
+	}
+
+	data, err := h.regions.GetCitiesByProvince(ctx, provinceID, c.QueryParam("search"))
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to get cities"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    data,
+		"message": "Get Cities Success",
+	})
+}
+
+// GetSubdistricts godoc
+// @Summary Get Subdistricts
+// @Description List the subdistricts of a city, or the ones matching search
+// @Tags Region
+// @Accept  json
+// @Produce  json
+// @Param city_id path int true "City ID"
+// @Param search query string false "Name prefix, typos are tolerated"
+// @Success 200
+// @Router /v1/region/subdistrict/{city_id} [get]
+func (h *RegionController) GetSubdistricts(c echo.Context) error {
+	ctx := c.Request().Context()
+	cityID, _ := strconv.Atoi(c.Param("city_id"))
+
+	if _, err := h.regions.GetCityByID(ctx, cityID); err != nil {
+		return regionLookupError(c, err, "City not found")
+	}
+
+	data, err := h.regions.GetSubdistrictsByCity(ctx, cityID, c.QueryParam("search"))
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to get subdistricts"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    data,
+		"message": "Get Subdistricts Success",
+	})
+}
+
+// SearchRegions godoc
+// @Summary Search Regions
+// @Description Search provinces, cities and subdistricts by name. Prefixes match and small typos are tolerated; every result carries the names of its parent regions.
+// @Tags Region
+// @Accept  json
+// @Produce  json
+// @Param q query string true "Name to search, at least 2 characters"
+// @Param level query string false "Only search one level: province, city or subdistrict"
+// @Param limit query int false "Maximum results, default 20, at most 100"
+// @Success 200
+// @Router /v1/region/search [get]
+func (h *RegionController) SearchRegions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	query := strings.TrimSpace(c.QueryParam("q"))
+	if utf8.RuneCountInString(query) < 2 {
+		return c.JSON(400, utils.NewBadRequestError("q must be at least 2 characters"))
+	}
+
+	level := c.QueryParam("level")
+	if level != "" && !utils.IsStringInArray(level, []string{reqres.RegionProvince, reqres.RegionCity, reqres.RegionSubdistrict}) {
+		return c.JSON(400, utils.NewBadRequestError("level must be province, city or subdistrict"))
+	}
+
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	data, err := h.regions.SearchRegions(ctx, query, level, limit)
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to search regions"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    data,
+		"message": "Search Regions Success",
+	})
+}
+
+// GetRegionsByPostalCode godoc
+// @Summary Get Regions By Postal Code
+// @Description List the cities using a postal code, with their province
+// @Tags Region
+// @Accept  json
+// @Produce  json
+// @Param code path string true "Postal code"
+// @Success 200
+// @Router /v1/region/postal-code/{code} [get]
+func (h *RegionController) GetRegionsByPostalCode(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	data, err := h.regions.GetCitiesByPostalCode(ctx, c.Param("code"))
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to get regions"))
+	}
+	if len(data) == 0 {
+		return c.JSON(http.StatusNotFound, utils.NewNotFoundError("No city uses this postal code"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    data,
+		"message": "Get Regions By Postal Code Success",
+	})
+}
+
+func regionLookupError(c echo.Context, err error, notFound string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.JSON(http.StatusNotFound, utils.NewNotFoundError(notFound))
+	}
+
+	return c.JSON(500, utils.Respond(500, err, "Failed to get region"))
+}
@@ -0,0 +1,235 @@
+package controllers
+
+import (
+	"net/http"
+	"project-name/app/repository"
+	"project-name/app/reqres"
+	"project-name/app/utils"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+)
+
+// RoleController serves the /v1/role and /v1/permission endpoints
+type RoleController struct {
+	roles repository.RoleRepository
+}
+
+func NewRoleController(roles repository.RoleRepository) *RoleController {
+	return &RoleController{roles: roles}
+}
+
+// CreateRole godoc
+// @Summary Create Role
+// @Description Create Role
+// @Tags Role
+// @Accept  json
+// @Produce  json
+// @Param request body reqres.RoleRequest true "Create Role Request"
+// @Success 200
+// @Router /v1/role [post]
+// @Security JwtToken
+func (h *RoleController) CreateRole(c echo.Context) error {
+	ctx := c.Request().Context()
+	var data reqres.RoleRequest
+	if err := c.Bind(&data); err != nil {
+		return c.JSON(400, utils.NewUnprocessableEntityError(err.Error()))
+	}
+
+	if err := data.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
+	}
+
+	role, err := h.roles.CreateRole(ctx, data)
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to create role"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    role,
+		"message": "Create Role Success",
+	})
+}
+
+// GetRoles godoc
+// @Summary Get Roles
+// @Description Get Roles
+// @Tags Role
+// @Accept  json
+// @Produce  json
+// @Success 200
+// @Param page query int false "Page"
+// @Param limit query int false "Limit"
+// @Param search query string false "Search"
+// @Param sort query string false "Sort"
+// @Param order query string false "Order"
+// @Param filter[field][op] query string false "Filter, e.g. filter[is_default]=true"
+// @Param cursor query string false "Keyset cursor from next_cursor or prev_cursor, send it empty for the first page"
+// @Param count query bool false "Count the total, defaults to true with offset paging and false with a cursor"
+// @Router /v1/role [get]
+// @Security JwtToken
+func (h *RoleController) GetRoles(c echo.Context) error {
+	ctx := c.Request().Context()
+	param := utils.PopulatePaging(c, "")
+
+	data, err := h.roles.GetRoles(ctx, param)
+	if errVal, ok := err.(validation.Errors); ok {
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
+	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to get roles"))
+	}
+
+	return c.JSON(200, data)
+}
+
+// GetAllRoles godoc
+// @Summary Get All Roles
+// @Description Get All Roles
+// @Tags Role
+// @Accept  json
+// @Produce  json
+// @Success 200
+// @Router /v1/role/all [get]
+// @Security JwtToken
+func (h *RoleController) GetAllRoles(c echo.Context) error {
+	ctx := c.Request().Context()
+	roles, err := h.roles.GetAllRoles(ctx)
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to get roles"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    roles,
+		"message": "Get All Roles Success",
+	})
+}
+
+// GetRoleByID godoc
+// @Summary Get Role By ID
+// @Description Get Role By ID
+// @Tags Role
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Role ID"
+// @Success 200
+// @Router /v1/role/{id} [get]
+// @Security JwtToken
+func (h *RoleController) GetRoleByID(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	data, err := h.roles.GetRoleByID(ctx, id)
+	if err != nil {
+		return c.JSON(400, utils.Respond(400, err, "Failed to get role"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    data,
+		"message": "Get Role Success",
+	})
+}
+
+// UpdateRole godoc
+// @Summary Update Role
+// @Description Update Role. Permissions are replaced when the list is sent.
+// @Tags Role
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Role ID"
+// @Param request body reqres.RoleRequest true "Update Role Request"
+// @Success 200
+// @Router /v1/role/{id} [put]
+// @Security JwtToken
+func (h *RoleController) UpdateRole(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	data, err := h.roles.GetRoleByID(ctx, id)
+	if err != nil {
+		return c.JSON(400, utils.Respond(400, err, "Failed to get role"))
+	}
+
+	var req reqres.RoleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(400, utils.NewUnprocessableEntityError(err.Error()))
+	}
+
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
+	}
+
+	update, err := h.roles.UpdateRole(ctx, data, req)
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to update role"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    update,
+		"message": "Update Role Success",
+	})
+}
+
+// DeleteRole godoc
+// @Summary Delete Role
+// @Description Delete Role. Roles that still have users cannot be deleted.
+// @Tags Role
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Role ID"
+// @Success 200
+// @Router /v1/role/{id} [delete]
+// @Security JwtToken
+func (h *RoleController) DeleteRole(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	data, err := h.roles.GetRoleByID(ctx, id)
+	if err != nil {
+		return c.JSON(400, utils.Respond(400, err, "Failed to get role"))
+	}
+
+	if h.roles.CountUsersWithRole(ctx, id) > 0 {
+		return c.JSON(400, utils.NewBadRequestError("Role is still assigned to users"))
+	}
+
+	if err := h.roles.DeleteRole(ctx, data); err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to delete role"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    data,
+		"message": "Delete Role Success",
+	})
+}
+
+// GetAllPermissions godoc
+// @Summary Get All Permissions
+// @Description Get All Permissions
+// @Tags Role
+// @Accept  json
+// @Produce  json
+// @Success 200
+// @Router /v1/permission/all [get]
+// @Security JwtToken
+func (h *RoleController) GetAllPermissions(c echo.Context) error {
+	ctx := c.Request().Context()
+	permissions, err := h.roles.GetAllPermissions(ctx)
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to get permissions"))
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status":  200,
+		"data":    permissions,
+		"message": "Get All Permissions Success",
+	})
+}
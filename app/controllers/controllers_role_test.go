@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"project-name/app/middlewares"
+	"project-name/app/models"
+	"project-name/app/repository"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// fakeRoles keeps roles in memory. Methods a test does not need panic through the
+// nil embedded interface.
+type fakeRoles struct {
+	repository.RoleRepository
+	roles       map[int]models.Role
+	users       map[int]int // user ID to role ID
+	permissions map[int][]string
+	deleted     []int
+}
+
+func (f *fakeRoles) UserHasPermission(ctx context.Context, userID int, permission string) bool {
+	roleID, ok := f.users[userID]
+	return ok && f.RoleHasPermission(ctx, roleID, permission)
+}
+
+func (f *fakeRoles) RoleHasPermission(ctx context.Context, roleID int, permission string) bool {
+	for _, p := range f.permissions[roleID] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleExceedsUser is true when the role grants a permission the user's role lacks
+func (f *fakeRoles) RoleExceedsUser(ctx context.Context, roleID, userID int) (bool, error) {
+	for _, p := range f.permissions[roleID] {
+		if !f.UserHasPermission(ctx, userID, p) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeRoles) GetDefaultRole(ctx context.Context) (models.Role, error) {
+	return f.GetRoleByID(ctx, 3)
+}
+
+func (f *fakeRoles) GetRoleByID(ctx context.Context, id int) (models.Role, error) {
+	data, ok := f.roles[id]
+	if !ok {
+		return models.Role{}, gorm.ErrRecordNotFound
+	}
+	return data, nil
+}
+
+func (f *fakeRoles) CountUsersWithRole(ctx context.Context, roleID int) (count int64) {
+	for _, id := range f.users {
+		if id == roleID {
+			count++
+		}
+	}
+	return
+}
+
+func (f *fakeRoles) DeleteRole(ctx context.Context, data models.Role) error {
+	delete(f.roles, int(data.ID))
+	f.deleted = append(f.deleted, int(data.ID))
+	return nil
+}
+
+func newFakeRoles() *fakeRoles {
+	roles := map[int]models.Role{}
+	for _, def := range repository.DefaultRoles {
+		role := models.Role{Name: def.Name}
+		role.ID = def.ID
+		roles[int(def.ID)] = role
+	}
+	auditor := models.Role{Name: "Auditor"}
+	auditor.ID = 4
+	roles[4] = auditor
+
+	return &fakeRoles{
+		roles: roles,
+		users: map[int]int{1: 2, 2: 3},
+		permissions: map[int][]string{
+			2: {"role.read", "role.delete"},
+			3: {"user.login"},
+		},
+	}
+}
+
+// serveRole runs the handler behind the permission check as the given user, like
+// the router does after Auth()
+func serveRole(roles *fakeRoles, method, target string, userID int, permission string, handler echo.HandlerFunc) *httptest.ResponseRecorder {
+	app := echo.New()
+	app.Add(method, "/v1/role/:id", handler, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("user_id", userID)
+			return next(c)
+		}
+	}, middlewares.NewAuthorizer(roles).RequirePermission(permission))
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+
+	return rec
+}
+
+func TestGetRoleByID(t *testing.T) {
+	roles := newFakeRoles()
+	h := NewRoleController(roles)
+
+	tests := []struct {
+		name   string
+		userID int
+		target string
+		status int
+	}{
+		{"granted", 1, "/v1/role/3", http.StatusOK},
+		{"missing permission", 2, "/v1/role/3", http.StatusForbidden},
+		{"unknown user", 9, "/v1/role/3", http.StatusForbidden},
+		{"unknown role", 1, "/v1/role/99", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveRole(roles, http.MethodGet, tt.target, tt.userID, "role.read", h.GetRoleByID)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+		})
+	}
+
+	rec := serveRole(roles, http.MethodGet, "/v1/role/3", 1, "role.read", h.GetRoleByID)
+	var body struct {
+		Data models.Role `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Data.ID != 3 || body.Data.Name != "User" {
+		t.Errorf("data = %+v", body.Data)
+	}
+}
+
+func TestDeleteRole(t *testing.T) {
+	roles := newFakeRoles()
+	h := NewRoleController(roles)
+
+	// user 2 still has the User role
+	rec := serveRole(roles, http.MethodDelete, "/v1/role/3", 1, "role.delete", h.DeleteRole)
+	if rec.Code != http.StatusBadRequest || len(roles.deleted) != 0 {
+		t.Fatalf("role in use: status = %d, deleted = %v", rec.Code, roles.deleted)
+	}
+
+	rec = serveRole(roles, http.MethodDelete, "/v1/role/4", 2, "role.delete", h.DeleteRole)
+	if rec.Code != http.StatusForbidden || len(roles.deleted) != 0 {
+		t.Fatalf("without permission: status = %d, deleted = %v", rec.Code, roles.deleted)
+	}
+
+	rec = serveRole(roles, http.MethodDelete, "/v1/role/4", 1, "role.delete", h.DeleteRole)
+	if rec.Code != http.StatusOK || len(roles.deleted) != 1 || roles.deleted[0] != 4 {
+		t.Fatalf("unused role: status = %d, deleted = %v", rec.Code, roles.deleted)
+	}
+	if _, ok := roles.roles[4]; ok {
+		t.Error("role 4 is still there")
+	}
+}
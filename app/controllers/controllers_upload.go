@@ -0,0 +1,352 @@
+package controllers
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"project-name/app/models"
+	"project-name/app/repository"
+	"project-name/app/storage"
+	"project-name/app/utils"
+	"project-name/config"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// UploadController serves resumable uploads following the tus protocol 1.0.0
+// with the creation, expiration and termination extensions, see https://tus.io.
+// Each PATCH is kept as one chunk: a PATCH cut off midway is discarded and the
+// client resumes from the offset returned by HEAD, so clients should send chunks
+// of a few megabytes.
+type UploadController struct {
+	files   repository.FileRepository
+	uploads repository.UploadRepository
+}
+
+func NewUploadController(files repository.FileRepository, uploads repository.UploadRepository) *UploadController {
+	return &UploadController{files: files, uploads: uploads}
+}
+
+// CreateUpload godoc
+// @Summary Create Resumable Upload
+// @Description Start a tus upload. The Location header is the URL to PATCH the content to, in chunks. Uploads not completed before Upload-Expires are deleted.
+// @Tags File
+// @Param Tus-Resumable header string true "1.0.0"
+// @Param Upload-Length header int true "Size of the whole file in bytes"
+// @Param Upload-Metadata header string false "Comma separated key and base64 value pairs, e.g. filename ZG9jLnBkZg==,filetype YXBwbGljYXRpb24vcGRm"
+// @Success 201
+// @Router /v1/file/tus [post]
+// @Security JwtToken
+func (h *UploadController) CreateUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Get("user_id").(int)
+
+	length, err := strconv.ParseInt(c.Request().Header.Get("Upload-Length"), 10, 64)
+	if err != nil || length < 1 {
+		return c.JSON(http.StatusBadRequest, utils.NewBadRequestError("Upload-Length must be a positive number of bytes"))
+	}
+	if length > config.LoadConfig().UploadMaxSize {
+		return c.JSON(http.StatusRequestEntityTooLarge, utils.NewHttpError(http.StatusRequestEntityTooLarge, "file too large", "Upload-Length is over Tus-Max-Size"))
+	}
+
+	rawMetadata := c.Request().Header.Get("Upload-Metadata")
+	metadata, err := parseUploadMetadata(rawMetadata)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, utils.NewBadRequestError(err.Error()))
+	}
+	// the declared type only saves uploading a file that would be refused, the
+	// content is checked again once complete
+	if fileType := metadata["filetype"]; fileType != "" && !uploadAllowedTypes[fileType] {
+		return c.JSON(http.StatusBadRequest, utils.NewBadRequestError("File type not allowed. Only PDF, JPEG, JPG, and PNG are accepted."))
+	}
+
+	uploadID, err := utils.GenerateSecureToken(24)
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to create upload"))
+	}
+
+	filename := metadata["filename"]
+	if filename == "" {
+		filename = "upload"
+	}
+
+	upload, err := h.uploads.CreateUpload(ctx, models.Upload{
+		UploadID:     uploadID,
+		OwnerID:      userID,
+		UploadLength: length,
+		Filename:     filename,
+		Metadata:     rawMetadata,
+		ExpiresAt:    uploadExpiry(),
+	})
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to create upload"))
+	}
+
+	c.Response().Header().Set("Location", strings.TrimSuffix(config.LoadConfig().BaseUrl, "/")+"/v1/file/tus/"+upload.UploadID)
+	setUploadHeaders(c, upload)
+
+	return c.NoContent(http.StatusCreated)
+}
+
+// GetUploadOffset godoc
+// @Summary Get Resumable Upload Offset
+// @Description Upload-Offset is how many bytes were received, PATCH the rest from there. Once complete, Upload-File-Id and Upload-File-Key name the stored file.
+// @Tags File
+// @Param Tus-Resumable header string true "1.0.0"
+// @Param id path string true "Upload ID"
+// @Success 200
+// @Router /v1/file/tus/{id} [head]
+// @Security JwtToken
+func (h *UploadController) GetUploadOffset(c echo.Context) error {
+	upload, status := h.upload(c)
+	if status != 0 {
+		return c.NoContent(status)
+	}
+
+	setUploadHeaders(c, upload)
+	c.Response().Header().Set("Cache-Control", "no-store")
+
+	return c.NoContent(http.StatusOK)
+}
+
+// PatchUpload godoc
+// @Summary Upload Chunk
+// @Description Append a chunk at Upload-Offset, which must equal the offset returned by HEAD, and be sent with Content-Length, 411 otherwise. The chunk completing the upload stores the file, once its type is checked, and answers with Upload-File-Id and Upload-File-Key, a concurrent request completing the same upload gets 409.
+// @Tags File
+// @Accept application/offset+octet-stream
+// @Param Tus-Resumable header string true "1.0.0"
+// @Param Upload-Offset header int true "Offset of the chunk"
+// @Param id path string true "Upload ID"
+// @Success 204
+// @Router /v1/file/tus/{id} [patch]
+// @Security JwtToken
+func (h *UploadController) PatchUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	upload, status := h.upload(c)
+	if status != 0 {
+		return c.NoContent(status)
+	}
+
+	if c.Request().Header.Get("Content-Type") != "application/offset+octet-stream" {
+		return c.NoContent(http.StatusUnsupportedMediaType)
+	}
+	// like tus, a chunk declares its size, the storage backends need it up front
+	if c.Request().ContentLength < 0 {
+		return c.NoContent(http.StatusLengthRequired)
+	}
+	offset, err := strconv.ParseInt(c.Request().Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if offset != upload.UploadOffset {
+		return c.NoContent(http.StatusConflict)
+	}
+
+	if upload.UploadOffset < upload.UploadLength {
+		upload, err = h.appendChunk(c, upload)
+		if err == repository.ErrUploadOffsetMismatch {
+			return c.NoContent(http.StatusConflict)
+		}
+		if errors.Is(err, errChunkTooLarge) {
+			return c.JSON(http.StatusRequestEntityTooLarge, utils.NewHttpError(http.StatusRequestEntityTooLarge, err.Error(), "The chunk goes past Upload-Length"))
+		}
+		if err != nil {
+			return c.JSON(500, utils.Respond(500, err, "Failed to save chunk"))
+		}
+	}
+
+	// the last chunk, or a retry after the file could not be stored
+	if upload.UploadOffset == upload.UploadLength && upload.CompletedAt == nil {
+		// two final PATCHes must not both store a file
+		upload, err = h.uploads.ClaimUpload(ctx, upload)
+		if err == repository.ErrUploadClaimed {
+			return c.NoContent(http.StatusConflict)
+		}
+		if err != nil {
+			return c.JSON(500, utils.Respond(500, err, "Failed to complete upload"))
+		}
+
+		file, err := h.completeUpload(c, upload)
+		var infected *fileInfectedError
+		// a file that failed to scan can be retried, the rest would fail again
+		if err == errFileTypeNotAllowed || errors.Is(err, errInvalidImage) || errors.As(err, &infected) {
+			h.uploads.DeleteUpload(ctx, upload)
+		} else if err != nil {
+			h.uploads.ReleaseUpload(ctx, upload)
+		}
+		if err == errFileTypeNotAllowed {
+			return c.JSON(http.StatusBadRequest, utils.NewBadRequestError("File type not allowed. Only PDF, JPEG, JPG, and PNG are accepted."))
+		}
+		if errors.Is(err, errInvalidImage) {
+			return c.JSON(http.StatusBadRequest, utils.NewBadRequestError("The image is corrupt or too large"))
+		}
+		if scanErr := scanErrorResponse(upload.Filename, err); scanErr != nil {
+			return c.JSON(scanErr.Status(), scanErr)
+		}
+		if err != nil {
+			return c.JSON(500, utils.Respond(500, err, "Failed to store upload"))
+		}
+
+		upload, err = h.uploads.CompleteUpload(ctx, upload, file.ID, uploadExpiry())
+		if err != nil {
+			return c.JSON(500, utils.Respond(500, err, "Failed to complete upload"))
+		}
+	}
+
+	setUploadHeaders(c, upload)
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteUpload godoc
+// @Summary Terminate Resumable Upload
+// @Description Cancel an upload and delete the received chunks. A file already stored from it is kept.
+// @Tags File
+// @Param Tus-Resumable header string true "1.0.0"
+// @Param id path string true "Upload ID"
+// @Success 204
+// @Router /v1/file/tus/{id} [delete]
+// @Security JwtToken
+func (h *UploadController) DeleteUpload(c echo.Context) error {
+	upload, status := h.upload(c)
+	if status != 0 {
+		return c.NoContent(status)
+	}
+
+	if err := h.uploads.DeleteUpload(c.Request().Context(), upload); err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to delete upload"))
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+var errChunkTooLarge = errors.New("chunk too large")
+
+// upload loads the upload of the path for its owner, or the status to answer with
+func (h *UploadController) upload(c echo.Context) (upload models.Upload, status int) {
+	upload, err := h.uploads.GetUpload(c.Request().Context(), c.Param("id"))
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && upload.OwnerID != c.Get("user_id").(int)) {
+		return upload, http.StatusNotFound
+	}
+	if err != nil {
+		c.Logger().Error(err)
+		return upload, http.StatusInternalServerError
+	}
+	if upload.CompletedAt == nil && time.Now().After(upload.ExpiresAt) {
+		return upload, http.StatusGone
+	}
+
+	return upload, 0
+}
+
+// appendChunk stores the request body as the next chunk of the upload
+func (h *UploadController) appendChunk(c echo.Context, upload models.Upload) (models.Upload, error) {
+	ctx := c.Request().Context()
+	remaining := upload.UploadLength - upload.UploadOffset
+
+	size := c.Request().ContentLength
+	if size > remaining {
+		return upload, errChunkTooLarge
+	}
+
+	suffix, err := utils.GenerateSecureToken(6)
+	if err != nil {
+		return upload, err
+	}
+	// concurrent PATCHes at the same offset write different objects, only the one
+	// that moves the offset first is kept
+	key := fmt.Sprintf("tus/%s/%020d-%s", upload.UploadID, upload.UploadOffset, suffix)
+
+	body := &countingReader{r: io.LimitReader(c.Request().Body, remaining+1)}
+	if err := storage.Default.Put(ctx, key, body, size, "application/octet-stream"); err != nil {
+		storage.Default.Delete(ctx, key)
+		return upload, err
+	}
+	if body.n > remaining {
+		storage.Default.Delete(ctx, key)
+		return upload, errChunkTooLarge
+	}
+	if body.n == 0 {
+		storage.Default.Delete(ctx, key)
+		return upload, nil
+	}
+
+	updated, err := h.uploads.AppendChunk(ctx, upload, key, body.n, uploadExpiry())
+	if err != nil {
+		storage.Default.Delete(ctx, key)
+		return upload, err
+	}
+
+	return updated, nil
+}
+
+// completeUpload joins the chunks into a file of the owner
+func (h *UploadController) completeUpload(c echo.Context, upload models.Upload) (models.File, error) {
+	ctx := c.Request().Context()
+
+	src := repository.OpenUpload(ctx, upload)
+	defer src.Close()
+
+	return saveUpload(ctx, h.files, upload.OwnerID, upload.Filename, src, uploadAllowedTypes)
+}
+
+func setUploadHeaders(c echo.Context, upload models.Upload) {
+	header := c.Response().Header()
+	header.Set("Upload-Offset", strconv.FormatInt(upload.UploadOffset, 10))
+	header.Set("Upload-Length", strconv.FormatInt(upload.UploadLength, 10))
+	if upload.Metadata != "" {
+		header.Set("Upload-Metadata", upload.Metadata)
+	}
+	if upload.CompletedAt == nil {
+		header.Set("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	}
+	if upload.File != nil {
+		header.Set("Upload-File-Id", strconv.FormatUint(uint64(upload.File.ID), 10))
+		header.Set("Upload-File-Key", upload.File.Key)
+	}
+}
+
+// uploadExpiry is when an upload receiving no chunk from now on expires
+func uploadExpiry() time.Time {
+	return time.Now().Add(time.Duration(config.LoadConfig().UploadExpiry) * time.Hour)
+}
+
+// parseUploadMetadata decodes the "key base64value,key base64value" of Upload-Metadata
+func parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := map[string]string{}
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, value, _ := strings.Cut(pair, " ")
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("Upload-Metadata value of %s is not base64", key)
+		}
+		metadata[key] = string(decoded)
+	}
+
+	return metadata, nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+
+	return n, err
+}
@@ -12,9 +12,20 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// UserController serves the /v1/user endpoints
+type UserController struct {
+	users   repository.UserRepository
+	regions repository.RegionRepository
+	roles   repository.RoleRepository
+}
+
+func NewUserController(users repository.UserRepository, regions repository.RegionRepository, roles repository.RoleRepository) *UserController {
+	return &UserController{users: users, regions: regions, roles: roles}
+}
+
 // CreateUser godoc
 // @Summary Create User
-// @Description Create User
+// @Description Create User. Without role_id the user gets the default role. Setting role_id needs the user.assign_role permission and only roles granting no more than your own.
 // @Tags User
 // @Accept  json
 // @Produce  json
@@ -22,33 +33,49 @@ import (
 // @Success 200
 // @Router /v1/user [post]
 // @Security JwtToken
-func CreateUser(c echo.Context) error {
+func (h *UserController) CreateUser(c echo.Context) error {
+	ctx := c.Request().Context()
 	var data reqres.UserRequest
 	if err := c.Bind(&data); err != nil {
 		return c.JSON(400, utils.NewUnprocessableEntityError(err.Error()))
 	}
 
-	if err := data.Validate(); err != nil {
-		errVal := err.(validation.Errors)
+	err := data.Validate(ctx, h.regions)
+	if errVal, ok := err.(validation.Errors); ok {
 		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
 	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to validate user"))
+	}
+
+	if data.RoleID != 0 {
+		if resp := h.checkRoleAssignment(c, data.RoleID); resp != nil {
+			return c.JSON(resp.Status(), resp)
+		}
+	} else {
+		// the same role a registration gets
+		role, err := h.roles.GetDefaultRole(ctx)
+		if err != nil {
+			return c.JSON(500, utils.Respond(500, err, "Failed to get default role"))
+		}
+		data.RoleID = int(role.ID)
+	}
 
 	if data.Email != "" {
-		email, _ := repository.GetUserByEmail(data.Email)
+		email, _ := h.users.GetUserByEmail(ctx, data.Email)
 		if email.Email != "" {
 			return c.JSON(400, utils.NewBadRequestError("Email already exists"))
 		}
 	}
 
 	if data.Phone != "" {
-		phone, _ := repository.GetUserByPhone(data.Phone)
+		phone, _ := h.users.GetUserByPhone(ctx, data.Phone)
 		if phone.Phone != "" {
 			return c.JSON(400, utils.NewBadRequestError("Phone already exists"))
 		}
 	}
 
 	var tglLahir time.Time
-	var err error
 	if data.TglLahir != "" {
 		tglLahir, err = time.Parse("2006-01-02 15:04:05", data.TglLahir)
 		if err != nil {
@@ -59,14 +86,22 @@ func CreateUser(c echo.Context) error {
 		}
 	}
 
-	user, err := repository.CreateUser(tglLahir, data)
+	user, err := h.users.CreateUser(ctx, tglLahir, data, c.Get("user_id").(int))
+	if err == repository.ErrFileUnavailable {
+		return c.JSON(400, utils.NewBadRequestError("Image not found, upload it first"))
+	}
 	if err != nil {
 		return c.JSON(500, utils.Respond(500, err, "Failed to create user"))
 	}
 
+	response, err := h.users.GetUserByID(ctx, int(user.ID))
+	if err != nil {
+		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
+	}
+
 	return c.JSON(200, map[string]interface{}{
 		"status":  200,
-		"data":    user,
+		"data":    response,
 		"message": "Create User Success",
 	})
 }
@@ -85,13 +120,25 @@ func CreateUser(c echo.Context) error {
 // @Param search query string false "Search"
 // @Param sort query string false "Sort"
 // @Param order query string false "Order"
+// @Param filter[field][op] query string false "Filter, e.g. filter[status]=1, filter[created_at][gte]=2025-01-01, filter[role_id][in]=1,2"
+// @Param cursor query string false "Keyset cursor from next_cursor or prev_cursor, send it empty for the first page"
+// @Param count query bool false "Count the total, defaults to true with offset paging and false with a cursor"
+// @Param fields query string false "Comma separated fields to return, e.g. id,name,email"
+// @Param expand query string false "Comma separated relations to load: role, province, city, subdistrict"
 // @Router /v1/user [get]
 // @Security JwtToken
-func GetUsers(c echo.Context) error {
+func (h *UserController) GetUsers(c echo.Context) error {
+	ctx := c.Request().Context()
 	roleID, _ := strconv.Atoi(c.QueryParam("role_id"))
 	param := utils.PopulatePaging(c, "status")
 
-	data := repository.GetUsers(roleID, param)
+	data, err := h.users.GetUsers(ctx, roleID, param)
+	if errVal, ok := err.(validation.Errors); ok {
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
+	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to get users"))
+	}
 
 	return c.JSON(200, data)
 }
@@ -105,9 +152,10 @@ func GetUsers(c echo.Context) error {
 // @Success 200
 // @Router /v1/user/all [get]
 // @Security JwtToken
-func GetAllUsers(c echo.Context) error {
+func (h *UserController) GetAllUsers(c echo.Context) error {
+	ctx := c.Request().Context()
 
-	users, err := repository.GetAllUsers()
+	users, err := h.users.GetAllUsers(ctx)
 	if err != nil {
 		return c.JSON(500, utils.Respond(500, err, "Failed to get users"))
 	}
@@ -126,13 +174,19 @@ func GetAllUsers(c echo.Context) error {
 // @Accept  json
 // @Produce  json
 // @Param id path int true "User ID"
+// @Param fields query string false "Comma separated fields to return, e.g. id,name,email"
+// @Param expand query string false "Comma separated relations to load: role, province, city, subdistrict"
 // @Success 200
 // @Router /v1/user/{id} [get]
 // @Security JwtToken
-func GetUserByID(c echo.Context) error {
+func (h *UserController) GetUserByID(c echo.Context) error {
+	ctx := c.Request().Context()
 	id, _ := strconv.Atoi(c.Param("id"))
 
-	data, err := repository.GetUserByID(id)
+	data, err := h.users.GetUserProjection(ctx, id, utils.PopulateProjection(c))
+	if errVal, ok := err.(validation.Errors); ok {
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
+	}
 	if err != nil {
 		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
 	}
@@ -146,7 +200,7 @@ func GetUserByID(c echo.Context) error {
 
 // UpdateUser godoc
 // @Summary Update User
-// @Description Update User
+// @Description Update User. Users whose role grants permissions you do not have cannot be changed, and changing the email marks the user unverified. Sending any of prov, kab and kec replaces the whole address, which must follow the province, city, subdistrict hierarchy. Changing role_id needs the user.assign_role permission, and neither the current nor the new role may grant more than your own.
 // @Tags User
 // @Accept  json
 // @Produce  json
@@ -155,29 +209,44 @@ func GetUserByID(c echo.Context) error {
 // @Success 200
 // @Router /v1/user/{id} [put]
 // @Security JwtToken
-func UpdateUser(c echo.Context) error {
+func (h *UserController) UpdateUser(c echo.Context) error {
+	ctx := c.Request().Context()
 	id, _ := strconv.Atoi(c.Param("id"))
 
-	data, err := repository.GetUserByIDPlain(id)
+	data, err := h.users.GetUserByIDPlain(ctx, id)
 	if err != nil {
 		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
 	}
 
+	if resp := h.checkTargetUser(c, data.RoleID); resp != nil {
+		return c.JSON(resp.Status(), resp)
+	}
+
 	var req reqres.UserUpdateRequest
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(400, utils.NewUnprocessableEntityError(err.Error()))
 	}
 
+	err = req.Validate(ctx, h.regions)
+	if errVal, ok := err.(validation.Errors); ok {
+		return c.JSON(http.StatusBadRequest, utils.NewInvalidInputError(errVal))
+	}
+	if err != nil {
+		return c.JSON(500, utils.Respond(500, err, "Failed to validate user"))
+	}
+
 	if req.Name != "" {
 		data.Name = req.Name
 	}
+	emailChanged := false
 	if req.Email != "" {
-		email, _ := repository.GetUserByEmail(req.Email)
+		email, _ := h.users.GetUserByEmail(ctx, req.Email)
 		if email.Email != "" {
 			if req.Email == email.Email && data.Email != email.Email {
 				return c.JSON(400, utils.NewBadRequestError("Email already exists"))
 			}
 		}
+		emailChanged = req.Email != data.Email
 		data.Email = req.Email
 	}
 	if req.Gender != "" {
@@ -198,7 +267,7 @@ func UpdateUser(c echo.Context) error {
 		data.Image = req.Image
 	}
 	if req.Phone != "" {
-		phone, _ := repository.GetUserByPhone(req.Phone)
+		phone, _ := h.users.GetUserByPhone(ctx, req.Phone)
 		if phone.Phone != "" {
 			if req.Phone == phone.Phone && data.Phone != phone.Phone {
 				return c.JSON(400, utils.NewBadRequestError("Phone already exists"))
@@ -209,17 +278,15 @@ func UpdateUser(c echo.Context) error {
 	if req.Address != "" {
 		data.Address = req.Address
 	}
-	if req.RoleID != 0 {
+	if req.RoleID != 0 && req.RoleID != data.RoleID {
+		// taking a role away is as sensitive as giving it
+		if resp := h.checkRoleAssignment(c, data.RoleID, req.RoleID); resp != nil {
+			return c.JSON(resp.Status(), resp)
+		}
 		data.RoleID = req.RoleID
 	}
-	if req.Prov != 0 {
-		data.Prov = req.Prov
-	}
-	if req.Kab != 0 {
-		data.Kab = req.Kab
-	}
-	if req.Kec != 0 {
-		data.Kec = req.Kec
+	if req.Prov != 0 || req.Kab != 0 || req.Kec != 0 {
+		data.Prov, data.Kab, data.Kec = req.Prov, req.Kab, req.Kec
 	}
 	if req.Kel != "" {
 		data.Kel = req.Kel
@@ -227,18 +294,31 @@ func UpdateUser(c echo.Context) error {
 	if req.PostalCode != "" {
 		data.PostalCode = req.PostalCode
 	}
-	data.IsVerify = req.IsVerify
-	data.Status = 0
-	if data.IsVerify {
-		data.Status = 1
+	isVerify := data.IsVerify
+	if req.IsVerify != nil {
+		isVerify = *req.IsVerify
+	}
+	if emailChanged {
+		// nobody has proven they own the new address yet
+		isVerify = false
+	}
+	if isVerify != data.IsVerify {
+		data.IsVerify = isVerify
+		data.Status = 0
+		if isVerify {
+			data.Status = 1
+		}
 	}
 
-	update, err := repository.UpdateUser(data)
+	update, err := h.users.UpdateUser(ctx, data, c.Get("user_id").(int))
+	if err == repository.ErrFileUnavailable {
+		return c.JSON(400, utils.NewBadRequestError("Image not found, upload it first"))
+	}
 	if err != nil {
 		return c.JSON(500, utils.Respond(500, err, "Failed to update user"))
 	}
 
-	dataUpdate, err := repository.GetUserByID(int(update.ID))
+	dataUpdate, err := h.users.GetUserByID(ctx, int(update.ID))
 	if err != nil {
 		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
 	}
@@ -252,7 +332,7 @@ func UpdateUser(c echo.Context) error {
 
 // DeleteUser godoc
 // @Summary Delete User
-// @Description Delete User
+// @Description Delete User. Users whose role grants permissions you do not have cannot be deleted.
 // @Tags User
 // @Accept  json
 // @Produce  json
@@ -260,29 +340,70 @@ func UpdateUser(c echo.Context) error {
 // @Success 200
 // @Router /v1/user/{id} [delete]
 // @Security JwtToken
-func DeleteUser(c echo.Context) error {
+func (h *UserController) DeleteUser(c echo.Context) error {
+	ctx := c.Request().Context()
 	id, _ := strconv.Atoi(c.Param("id"))
 
-	data, err := repository.GetUserByIDPlain(id)
+	data, err := h.users.GetUserByIDPlain(ctx, id)
 	if err != nil {
 		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
 	}
 
-	dataResponse, err := repository.GetUserByID(id)
+	if resp := h.checkTargetUser(c, data.RoleID); resp != nil {
+		return c.JSON(resp.Status(), resp)
+	}
+
+	dataResponse, err := h.users.GetUserByID(ctx, id)
 	if err != nil {
 		return c.JSON(400, utils.Respond(400, err, "Failed to get user"))
 	}
 
-	_, err = repository.DeleteUser(data)
+	_, err = h.users.DeleteUser(ctx, data)
 	if err != nil {
 		return c.JSON(500, utils.Respond(500, err, "Failed to delete user"))
 	}
 
-	DeleteFile(data.Image)
-
 	return c.JSON(200, map[string]interface{}{
 		"status":  200,
 		"data":    dataResponse,
 		"message": "Delete User Success",
 	})
 }
+
+// checkRoleAssignment refuses to set roles unless the signed in user has the
+// user.assign_role permission and every permission the roles grant, which keeps
+// anyone from handing out more access than they hold
+func (h *UserController) checkRoleAssignment(c echo.Context, roleIDs ...int) utils.HttpErr {
+	ctx := c.Request().Context()
+	userID := c.Get("user_id").(int)
+	if !h.roles.UserHasPermission(ctx, userID, "user.assign_role") {
+		return utils.NewForbiddenError("Missing permission user.assign_role")
+	}
+
+	for _, roleID := range roleIDs {
+		exceeds, err := h.roles.RoleExceedsUser(ctx, roleID, userID)
+		if err != nil {
+			return utils.NewInternalServerError(err)
+		}
+		if exceeds {
+			return utils.NewForbiddenError("The role grants permissions you do not have")
+		}
+	}
+
+	return nil
+}
+
+// checkTargetUser refuses to change or delete a user whose role grants permissions
+// the signed in user does not have. Otherwise an admin could take over a super
+// admin by changing their email and resetting the password.
+func (h *UserController) checkTargetUser(c echo.Context, roleID int) utils.HttpErr {
+	exceeds, err := h.roles.RoleExceedsUser(c.Request().Context(), roleID, c.Get("user_id").(int))
+	if err != nil {
+		return utils.NewInternalServerError(err)
+	}
+	if exceeds {
+		return utils.NewForbiddenError("The user has permissions you do not have")
+	}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"project-name/app/middlewares"
+	"project-name/app/models"
+	"project-name/app/repository"
+	"project-name/app/reqres"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// fakeUsers keeps users in memory, by ID
+type fakeUsers struct {
+	repository.UserRepository
+	users map[int]models.User
+}
+
+func (f *fakeUsers) CreateUser(ctx context.Context, tglLahir time.Time, data reqres.UserRequest, imageOwnerID int) (models.User, error) {
+	user := models.User{Name: data.Name, Email: data.Email, RoleID: data.RoleID}
+	user.ID = uint(len(f.users) + 1)
+	f.users[int(user.ID)] = user
+	return user, nil
+}
+
+func (f *fakeUsers) GetUserByIDPlain(ctx context.Context, id int) (models.User, error) {
+	data, ok := f.users[id]
+	if !ok {
+		return models.User{}, gorm.ErrRecordNotFound
+	}
+	return data, nil
+}
+
+func (f *fakeUsers) GetUserByID(ctx context.Context, id int) (reqres.UserResponse, error) {
+	data, err := f.GetUserByIDPlain(ctx, id)
+	if err != nil {
+		return reqres.UserResponse{}, err
+	}
+	return reqres.UserResponse{CustomGormModel: data.CustomGormModel, Name: data.Name, Email: data.Email, RoleID: data.RoleID, IsVerify: data.IsVerify, Status: data.Status}, nil
+}
+
+func (f *fakeUsers) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
+	for _, data := range f.users {
+		if data.Email == email {
+			return data, nil
+		}
+	}
+	return models.User{}, gorm.ErrRecordNotFound
+}
+
+func (f *fakeUsers) GetUserByPhone(ctx context.Context, phone string) (models.User, error) {
+	for _, data := range f.users {
+		if data.Phone == phone {
+			return data, nil
+		}
+	}
+	return models.User{}, gorm.ErrRecordNotFound
+}
+
+func (f *fakeUsers) UpdateUser(ctx context.Context, data models.User, imageOwnerID int) (models.User, error) {
+	f.users[int(data.ID)] = data
+	return data, nil
+}
+
+func (f *fakeUsers) DeleteUser(ctx context.Context, data models.User) (models.User, error) {
+	delete(f.users, int(data.ID))
+	return data, nil
+}
+
+// newUserFixture has a super admin (1), an admin (2) and a user (3), with their
+// seeded permissions
+func newUserFixture() (*fakeUsers, *fakeRoles) {
+	roles := newFakeRoles()
+	roles.users = map[int]int{}
+	for _, def := range repository.DefaultRoles {
+		roles.permissions[int(def.ID)] = def.Permissions
+	}
+	// the seeded super admin gets every permission
+	for _, p := range repository.DefaultPermissions {
+		roles.permissions[1] = append(roles.permissions[1], p.Name)
+	}
+
+	users := &fakeUsers{users: map[int]models.User{}}
+	for id, name := range map[int]string{1: "root", 2: "admin", 3: "budi"} {
+		data := models.User{Name: name, Email: name + "@example.com", RoleID: id, IsVerify: true, Status: 1}
+		data.ID = uint(id)
+		users.users[id] = data
+		roles.users[id] = id
+	}
+
+	return users, roles
+}
+
+// serveUser runs the handler behind the permission check as the given user
+func serveUser(roles *fakeRoles, method, target string, userID int, permission, body string, handler echo.HandlerFunc) *httptest.ResponseRecorder {
+	app := echo.New()
+	app.Add(method, "/v1/user/:id", handler, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("user_id", userID)
+			return next(c)
+		}
+	}, middlewares.NewAuthorizer(roles).RequirePermission(permission))
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestUpdateUserTarget(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID int
+		target string
+		body   string
+		status int
+	}{
+		{"admin cannot change a super admin", 2, "/v1/user/1", `{"email":"mine@example.com"}`, http.StatusForbidden},
+		{"admin changes a user", 2, "/v1/user/3", `{"name":"Budi"}`, http.StatusOK},
+		{"super admin changes an admin", 1, "/v1/user/2", `{"name":"Admin"}`, http.StatusOK},
+		{"user without permission", 3, "/v1/user/3", `{"name":"Budi"}`, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, roles := newUserFixture()
+			before := users.users[1]
+			h := NewUserController(users, nil, roles)
+
+			rec := serveUser(roles, http.MethodPut, tt.target, tt.userID, "user.update", tt.body, h.UpdateUser)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if users.users[1] != before {
+				t.Errorf("super admin changed to %+v", users.users[1])
+			}
+		})
+	}
+}
+
+func TestUpdateUserEmailClearsVerification(t *testing.T) {
+	users, roles := newUserFixture()
+	h := NewUserController(users, nil, roles)
+
+	rec := serveUser(roles, http.MethodPut, "/v1/user/3", 2, "user.update", `{"name":"Budi","is_verify":true}`, h.UpdateUser)
+	if rec.Code != http.StatusOK || !users.users[3].IsVerify {
+		t.Fatalf("same email: status = %d, user = %+v", rec.Code, users.users[3])
+	}
+
+	rec = serveUser(roles, http.MethodPut, "/v1/user/3", 2, "user.update", `{"email":"new@example.com","is_verify":true}`, h.UpdateUser)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if got := users.users[3]; got.Email != "new@example.com" || got.IsVerify || got.Status != 0 {
+		t.Errorf("user = %+v, want the new email unverified", got)
+	}
+}
+
+func TestDeleteUserTarget(t *testing.T) {
+	users, roles := newUserFixture()
+	h := NewUserController(users, nil, roles)
+
+	rec := serveUser(roles, http.MethodDelete, "/v1/user/1", 2, "user.delete", "", h.DeleteUser)
+	if _, ok := users.users[1]; rec.Code != http.StatusForbidden || !ok {
+		t.Fatalf("super admin: status = %d, still there = %v", rec.Code, ok)
+	}
+
+	rec = serveUser(roles, http.MethodDelete, "/v1/user/3", 2, "user.delete", "", h.DeleteUser)
+	if _, ok := users.users[3]; rec.Code != http.StatusOK || ok {
+		t.Fatalf("user: status = %d, still there = %v", rec.Code, ok)
+	}
+}
+
+func TestUpdateUserVerification(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		isVerify bool
+		status   int
+	}{
+		{"left out", `{"name":"Budi"}`, true, 1},
+		{"unverify", `{"is_verify":false}`, false, 0},
+		{"verify again", `{"is_verify":true}`, true, 1},
+	}
+	users, roles := newUserFixture()
+	h := NewUserController(users, nil, roles)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveUser(roles, http.MethodPut, "/v1/user/3", 2, "user.update", tt.body, h.UpdateUser)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+			}
+			if got := users.users[3]; got.IsVerify != tt.isVerify || got.Status != tt.status {
+				t.Errorf("is_verify = %v, status = %d, want %v, %d", got.IsVerify, got.Status, tt.isVerify, tt.status)
+			}
+		})
+	}
+}
+
+func TestCreateUserRole(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		roleID int
+	}{
+		{"default role", `{"name":"Siti","email":"siti@example.com","password":"secret"}`, http.StatusOK, 3},
+		{"assigned role", `{"name":"Siti","email":"siti@example.com","password":"secret","role_id":2}`, http.StatusOK, 2},
+		{"role above the caller", `{"name":"Siti","email":"siti@example.com","password":"secret","role_id":1}`, http.StatusForbidden, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, roles := newUserFixture()
+			h := NewUserController(users, nil, roles)
+
+			app := echo.New()
+			app.POST("/v1/user", h.CreateUser, func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					c.Set("user_id", 2)
+					return next(c)
+				}
+			})
+			req := httptest.NewRequest(http.MethodPost, "/v1/user", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			created, ok := users.users[4]
+			if tt.roleID == 0 && ok {
+				t.Errorf("created %+v", created)
+			}
+			if tt.roleID != 0 && (!ok || created.RoleID != tt.roleID) {
+				t.Errorf("created = %+v, want role %d", created, tt.roleID)
+			}
+		})
+	}
+}
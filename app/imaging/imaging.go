@@ -0,0 +1,272 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"project-name/config"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnsupported   = errors.New("unsupported image type")
+	ErrImageTooLarge = errors.New("image has too many pixels")
+)
+
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatWebP = "webp"
+)
+
+var mimeTypes = map[string]string{
+	FormatJPEG: "image/jpeg",
+	FormatPNG:  "image/png",
+	FormatWebP: "image/webp",
+}
+
+var extensions = map[string]string{
+	FormatJPEG: ".jpg",
+	FormatPNG:  ".png",
+	FormatWebP: ".webp",
+}
+
+// Variant is a resized copy generated for every uploaded image. The image is
+// scaled down to fit Width x Height, a zero bound leaves that side free.
+type Variant struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+// Output is one encoded image
+type Output struct {
+	Name     string
+	Data     []byte
+	Format   string
+	MimeType string
+	Ext      string
+	Width    int
+	Height   int
+}
+
+// Pipeline normalizes uploaded images and generates their variants
+type Pipeline struct {
+	Variants []Variant
+	// Format of the variants, empty keeps the format of the upload
+	Format    string
+	Quality   int
+	MaxPixels int
+	// WebPEncoder is the path of cwebp, the standard library cannot encode WebP
+	WebPEncoder string
+}
+
+// Default is the pipeline configured by the IMAGE_* settings, nil when image
+// processing is disabled
+var Default *Pipeline
+
+// Init sets Default from the configuration
+func Init() {
+	cfg := config.LoadConfig()
+	if !cfg.EnableImageProcessing {
+		return
+	}
+
+	variants, err := ParseVariants(cfg.ImageVariants)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	pipeline := &Pipeline{
+		Variants:  variants,
+		Format:    strings.ToLower(cfg.ImageVariantFormat),
+		Quality:   cfg.ImageQuality,
+		MaxPixels: cfg.ImageMaxPixels,
+	}
+
+	switch pipeline.Format {
+	case "", FormatJPEG, FormatPNG:
+	case FormatWebP:
+		encoder, err := exec.LookPath(cfg.ImageWebPEncoder)
+		if err != nil {
+			log.Printf("Warning: %s not found, image variants keep the format of the upload instead of WebP", cfg.ImageWebPEncoder)
+			pipeline.Format = ""
+		}
+		pipeline.WebPEncoder = encoder
+	default:
+		log.Panicf("Unknown IMAGE_VARIANT_FORMAT %q", cfg.ImageVariantFormat)
+	}
+
+	Default = pipeline
+}
+
+// ParseVariants reads a list such as "thumb:150x150,medium:640x640,large:1280x0"
+func ParseVariants(spec string) (variants []Variant, err error) {
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, size, ok := strings.Cut(item, ":")
+		width, height, ok2 := strings.Cut(size, "x")
+		if !ok || !ok2 || name == "" || strings.ContainsAny(name, "/\\. ") {
+			return nil, fmt.Errorf("invalid image variant %q, expected name:WIDTHxHEIGHT", item)
+		}
+
+		variant := Variant{Name: name}
+		variant.Width, err = strconv.Atoi(width)
+		if err == nil {
+			variant.Height, err = strconv.Atoi(height)
+		}
+		if err != nil || variant.Width < 0 || variant.Height < 0 || variant.Width+variant.Height == 0 {
+			return nil, fmt.Errorf("invalid image variant %q, expected name:WIDTHxHEIGHT", item)
+		}
+
+		variants = append(variants, variant)
+	}
+
+	return
+}
+
+// Supported reports whether the pipeline processes files of mimeType
+func Supported(mimeType string) bool {
+	return mimeType == mimeTypes[FormatJPEG] || mimeType == mimeTypes[FormatPNG]
+}
+
+// Process decodes a JPEG or PNG upload and returns it re-encoded in its own
+// format, upright and without metadata, followed by one output per variant
+func (p *Pipeline) Process(data []byte) (original Output, variants []Output, err error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+	if format != FormatJPEG && format != FormatPNG {
+		err = ErrUnsupported
+		return
+	}
+	if p.MaxPixels > 0 && cfg.Width*cfg.Height > p.MaxPixels {
+		err = ErrImageTooLarge
+		return
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+	if format == FormatJPEG {
+		img = Orient(img, Orientation(data))
+	}
+
+	// re-encoding drops every metadata segment, EXIF GPS data included
+	original, err = p.encode(img, format)
+	if err != nil {
+		return
+	}
+
+	variantFormat := p.Format
+	if variantFormat == "" {
+		variantFormat = format
+	}
+
+	for _, variant := range p.Variants {
+		width, height := Fit(img.Bounds().Dx(), img.Bounds().Dy(), variant.Width, variant.Height)
+
+		var output Output
+		output, err = p.encode(Resize(img, width, height), variantFormat)
+		if err != nil {
+			return
+		}
+		output.Name = variant.Name
+		variants = append(variants, output)
+	}
+
+	return
+}
+
+func (p *Pipeline) encode(img image.Image, format string) (output Output, err error) {
+	var buf bytes.Buffer
+
+	switch format {
+	case FormatJPEG:
+		err = jpeg.Encode(&buf, flatten(img), &jpeg.Options{Quality: p.Quality})
+	case FormatPNG:
+		err = (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(&buf, img)
+	case FormatWebP:
+		err = p.encodeWebP(&buf, img)
+	default:
+		err = ErrUnsupported
+	}
+	if err != nil {
+		return
+	}
+
+	return Output{
+		Data:     buf.Bytes(),
+		Format:   format,
+		MimeType: mimeTypes[format],
+		Ext:      extensions[format],
+		Width:    img.Bounds().Dx(),
+		Height:   img.Bounds().Dy(),
+	}, nil
+}
+
+// encodeWebP hands a lossless PNG of img to cwebp
+func (p *Pipeline) encodeWebP(buf *bytes.Buffer, img image.Image) error {
+	dir, err := os.MkdirTemp("", "webp-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	in, out := filepath.Join(dir, "in.png"), filepath.Join(dir, "out.webp")
+
+	file, err := os.Create(in)
+	if err != nil {
+		return err
+	}
+	err = (&png.Encoder{CompressionLevel: png.NoCompression}).Encode(file, img)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(p.WebPEncoder, "-quiet", "-metadata", "none", "-q", strconv.Itoa(p.Quality), in, "-o", out)
+	if message, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("cwebp: %v: %s", err, strings.TrimSpace(string(message)))
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+
+	return nil
+}
+
+// flatten puts transparent images on white, JPEG has no alpha channel
+func flatten(img image.Image) image.Image {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && opaque.Opaque() {
+		return img
+	}
+
+	bounds := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Over)
+
+	return dst
+}
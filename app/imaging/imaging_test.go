@@ -0,0 +1,108 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// withOrientation inserts an EXIF segment holding orientation after the SOI marker
+func withOrientation(t *testing.T, img image.Image, orientation uint16) []byte {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3) // SHORT
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := binary.BigEndian.AppendUint16([]byte{0xFF, 0xE1}, uint16(len(segment)+2))
+
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), append(app1, segment...)...), data[2:]...)
+}
+
+func TestProcessOrientsAndResizes(t *testing.T) {
+	// 40x20, left half black, right half white
+	src := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 20; x < 40; x++ {
+			src.Set(x, y, color.White)
+		}
+	}
+	data := withOrientation(t, src, 6)
+
+	if got := Orientation(data); got != 6 {
+		t.Fatalf("orientation = %d", got)
+	}
+
+	p := &Pipeline{Variants: []Variant{{Name: "thumb", Width: 5, Height: 5}}, Quality: 90}
+	original, variants, err := p.Process(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// rotated clockwise the black half ends up on top
+	if original.Width != 20 || original.Height != 40 || original.MimeType != "image/jpeg" {
+		t.Fatalf("original = %dx%d %s", original.Width, original.Height, original.MimeType)
+	}
+	if Orientation(original.Data) != 1 {
+		t.Error("original still carries EXIF")
+	}
+	decoded, err := jpeg.Decode(bytes.NewReader(original.Data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if top, _, _, _ := decoded.At(10, 5).RGBA(); top > 0x2000 {
+		t.Errorf("top is not black: %x", top)
+	}
+	if bottom, _, _, _ := decoded.At(10, 35).RGBA(); bottom < 0xE000 {
+		t.Errorf("bottom is not white: %x", bottom)
+	}
+
+	if len(variants) != 1 || variants[0].Name != "thumb" || variants[0].Width != 3 || variants[0].Height != 5 {
+		t.Fatalf("variants = %+v", variants)
+	}
+}
+
+func TestProcessKeepsPNGTransparency(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	var buf bytes.Buffer
+	png.Encode(&buf, src)
+
+	p := &Pipeline{Variants: []Variant{{Name: "small", Width: 4}}, Quality: 90}
+	original, variants, err := p.Process(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if original.MimeType != "image/png" || variants[0].Width != 4 || variants[0].Height != 4 {
+		t.Fatalf("original %s, variants %+v", original.MimeType, variants)
+	}
+
+	decoded, _ := png.Decode(bytes.NewReader(variants[0].Data))
+	if _, _, _, a := decoded.At(1, 1).RGBA(); a != 0 {
+		t.Errorf("alpha = %d", a)
+	}
+}
+
+func TestParseVariants(t *testing.T) {
+	variants, err := ParseVariants("thumb:150x150, large:1280x0")
+	if err != nil || len(variants) != 2 || variants[1] != (Variant{Name: "large", Width: 1280}) {
+		t.Fatalf("variants = %+v, %v", variants, err)
+	}
+
+	for _, spec := range []string{"thumb", "thumb:0x0", "../x:1x1", "thumb:axb"} {
+		if _, err := ParseVariants(spec); err == nil {
+			t.Errorf("%q parsed", spec)
+		}
+	}
+}
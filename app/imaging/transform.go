@@ -0,0 +1,246 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+	"math"
+)
+
+// Orientation reads the EXIF orientation of a JPEG, 1 (upright) when there is none
+func Orientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xFF {
+			i++ // fill byte
+			continue
+		}
+		if marker == 0xD8 || marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			i += 2
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 {
+			// image data starts, EXIF always comes before it
+			return 1
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+
+		i += 2 + length
+	}
+
+	return 1
+}
+
+// exifOrientation finds the orientation tag in IFD0 of a TIFF header
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[offset:]))
+	for n := 0; n < count; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		// tag 0x0112 is a SHORT stored in the first bytes of the value field
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			value := int(order.Uint16(tiff[entry+8:]))
+			if value < 1 || value > 8 {
+				return 1
+			}
+			return value
+		}
+	}
+
+	return 1
+}
+
+// Orient turns img upright according to an EXIF orientation
+func Orient(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	src := toRGBA(img)
+
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // mirrored
+				sx, sy = w-1-x, y
+			case 3: // upside down
+				sx, sy = w-1-x, h-1-y
+			case 4: // mirrored upside down
+				sx, sy = x, h-1-y
+			case 5: // mirrored, rotated 90° counterclockwise
+				sx, sy = y, x
+			case 6: // rotated 90° counterclockwise
+				sx, sy = y, h-1-x
+			case 7: // mirrored, rotated 90° clockwise
+				sx, sy = w-1-y, h-1-x
+			case 8: // rotated 90° clockwise
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+
+	return dst
+}
+
+// Fit scales width x height down to fit maxWidth x maxHeight, keeping the aspect
+// ratio. Images are never enlarged and a zero bound is ignored.
+func Fit(width, height, maxWidth, maxHeight int) (int, int) {
+	scale := 1.0
+	if maxWidth > 0 {
+		scale = math.Min(scale, float64(maxWidth)/float64(width))
+	}
+	if maxHeight > 0 {
+		scale = math.Min(scale, float64(maxHeight)/float64(height))
+	}
+
+	return max(1, int(math.Round(float64(width)*scale))), max(1, int(math.Round(float64(height)*scale)))
+}
+
+// Resize scales img to width x height with a triangle filter widened to the
+// scale factor, which averages every source pixel when shrinking
+func Resize(img image.Image, width, height int) image.Image {
+	src := toRGBA(img)
+	if src.Bounds().Dx() == width && src.Bounds().Dy() == height {
+		return src
+	}
+
+	// the horizontal pass keeps the source height, the vertical one finishes
+	tmp := image.NewRGBA(image.Rect(0, 0, width, src.Bounds().Dy()))
+	resample(src, tmp, true)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	resample(tmp, dst, false)
+
+	return dst
+}
+
+type weight struct {
+	index  int
+	weight float64
+}
+
+// weights lists for every destination pixel the source pixels it averages
+func weights(srcSize, dstSize int) [][]weight {
+	scale := float64(srcSize) / float64(dstSize)
+	radius := math.Max(scale, 1)
+
+	table := make([][]weight, dstSize)
+	for i := range table {
+		center := (float64(i)+0.5)*scale - 0.5
+		var sum float64
+		for j := int(math.Ceil(center - radius)); j <= int(math.Floor(center+radius)); j++ {
+			w := 1 - math.Abs(float64(j)-center)/radius
+			if w <= 0 {
+				continue
+			}
+			table[i] = append(table[i], weight{index: min(max(j, 0), srcSize-1), weight: w})
+			sum += w
+		}
+		for k := range table[i] {
+			table[i][k].weight /= sum
+		}
+	}
+
+	return table
+}
+
+func resample(src, dst *image.RGBA, horizontal bool) {
+	bounds := dst.Bounds()
+	var table [][]weight
+	if horizontal {
+		table = weights(src.Bounds().Dx(), bounds.Dx())
+	} else {
+		table = weights(src.Bounds().Dy(), bounds.Dy())
+	}
+
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			var r, g, b, a float64
+			i := y
+			if horizontal {
+				i = x
+			}
+			for _, w := range table[i] {
+				offset := src.PixOffset(x, w.index)
+				if horizontal {
+					offset = src.PixOffset(w.index, y)
+				}
+				pix := src.Pix[offset : offset+4]
+				r += float64(pix[0]) * w.weight
+				g += float64(pix[1]) * w.weight
+				b += float64(pix[2]) * w.weight
+				a += float64(pix[3]) * w.weight
+			}
+
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = clamp(r)
+			dst.Pix[offset+1] = clamp(g)
+			dst.Pix[offset+2] = clamp(b)
+			dst.Pix[offset+3] = clamp(a)
+		}
+	}
+}
+
+func clamp(v float64) uint8 {
+	return uint8(min(max(math.Round(v), 0), 255))
+}
+
+// toRGBA copies img into a premultiplied RGBA image at the origin, averaging
+// premultiplied colors keeps transparent pixels from bleeding into the edges
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Bounds().Min == (image.Point{}) {
+		return rgba
+	}
+
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+
+	return rgba
+}
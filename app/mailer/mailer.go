@@ -0,0 +1,117 @@
+package mailer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"project-name/config"
+	"strings"
+	"time"
+)
+
+// Message is a rendered email with a plain text and an HTML alternative
+type Message struct {
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer delivers rendered messages
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// Default returns the mailer selected by MAIL_DRIVER, "smtp" or "file"
+func Default() Mailer {
+	cfg := config.LoadConfig()
+
+	if cfg.MailDriver == "FILE" {
+		m := NewFileMailer(cfg.MailOutboxDir)
+		if cfg.SmtpSender != "" {
+			m.From = cfg.SmtpSender
+		}
+		return m
+	}
+
+	return NewSMTPMailer(cfg.SmtpHost, cfg.SmtpPort, cfg.SmtpSender, cfg.SmtpPassword, cfg.SmtpSender, cfg.AppName)
+}
+
+// Build encodes the message as a multipart/alternative MIME document
+func (msg Message) Build(from string, fromName string) ([]byte, error) {
+	if len(msg.To) == 0 {
+		return nil, fmt.Errorf("mailer: message has no recipient")
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	sender := mail.Address{Name: fromName, Address: from}
+
+	headers := []string{
+		"From: " + sender.String(),
+		"To: " + strings.Join(msg.To, ", "),
+		"Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"Message-ID: " + messageID(from),
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + writer.Boundary(),
+	}
+
+	var out bytes.Buffer
+	out.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	for _, part := range []struct {
+		contentType string
+		body        string
+	}{
+		{"text/plain; charset=UTF-8", msg.Text},
+		{"text/html; charset=UTF-8", msg.HTML},
+	} {
+		if part.body == "" {
+			continue
+		}
+
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	out.Write(buf.Bytes())
+
+	return out.Bytes(), nil
+}
+
+func messageID(from string) string {
+	b := make([]byte, 12)
+	rand.Read(b)
+
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 {
+		domain = from[at+1:]
+	}
+
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">"
+}
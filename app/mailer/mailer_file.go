@@ -0,0 +1,45 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileMailer writes every message as an .eml file into Dir instead of sending it.
+// It is meant for development and tests, the files open in any mail client.
+type FileMailer struct {
+	Dir  string
+	From string
+
+	mu  sync.Mutex
+	seq int
+}
+
+func NewFileMailer(dir string) *FileMailer {
+	return &FileMailer{Dir: dir, From: "outbox@localhost"}
+}
+
+func (m *FileMailer) Send(msg Message) error {
+	body, err := msg.Build(m.From, "")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.Dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.seq++
+	seq := m.seq
+	m.mu.Unlock()
+
+	to := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(msg.To[0])
+	name := fmt.Sprintf("%s-%04d-%s.eml", time.Now().Format("20060102T150405.000000000"), seq, to)
+
+	return os.WriteFile(filepath.Join(m.Dir, name), body, 0644)
+}
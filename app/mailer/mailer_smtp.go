@@ -0,0 +1,120 @@
+package mailer
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTPMailer sends through an SMTP server. Port 465 uses implicit TLS, any other
+// port starts in plain text and upgrades with STARTTLS when the server offers it.
+type SMTPMailer struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	FromName string
+
+	// TLSConfig overrides the TLS settings, mainly to trust a test server
+	TLSConfig *tls.Config
+	Timeout   time.Duration
+}
+
+func NewSMTPMailer(host string, port int, username, password, from, fromName string) *SMTPMailer {
+	return &SMTPMailer{
+		Host:     host,
+		Port:     port,
+		Username: username,
+		Password: password,
+		From:     from,
+		FromName: fromName,
+		Timeout:  30 * time.Second,
+	}
+}
+
+func (m *SMTPMailer) tlsConfig() *tls.Config {
+	if m.TLSConfig != nil {
+		return m.TLSConfig
+	}
+	return &tls.Config{ServerName: m.Host}
+}
+
+func (m *SMTPMailer) dial() (*smtp.Client, error) {
+	addr := net.JoinHostPort(m.Host, strconv.Itoa(m.Port))
+	dialer := &net.Dialer{Timeout: m.Timeout}
+
+	if m.Port == 465 {
+		conn, err := tls.DialWithDialer(dialer, "tcp", addr, m.tlsConfig())
+		if err != nil {
+			return nil, err
+		}
+		return smtp.NewClient(conn, m.Host)
+	}
+
+	conn, err := dialer.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(conn, m.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(m.tlsConfig()); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
+func (m *SMTPMailer) Send(msg Message) error {
+	body, err := msg.Build(m.From, m.FromName)
+	if err != nil {
+		return err
+	}
+
+	client, err := m.dial()
+	if err != nil {
+		return fmt.Errorf("mailer: connect: %w", err)
+	}
+	defer client.Close()
+
+	if m.Username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err := client.Auth(smtp.PlainAuth("", m.Username, m.Password, m.Host)); err != nil {
+				return fmt.Errorf("mailer: auth: %w", err)
+			}
+		}
+	}
+
+	if err := client.Mail(m.From); err != nil {
+		return fmt.Errorf("mailer: mail from: %w", err)
+	}
+	for _, to := range msg.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("mailer: rcpt %s: %w", to, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("mailer: data: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("mailer: data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("mailer: data: %w", err)
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,172 @@
+package mailer
+
+import (
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// received is what fakeSMTP recorded of one session
+type received struct {
+	auth string
+	from string
+	rcpt []string
+	data string
+}
+
+// fakeSMTP speaks enough SMTP to accept one message per connection without TLS,
+// offering AUTH PLAIN and refusing recipients at reject.example.com. Every session
+// is sent to the channel once the client hangs up.
+func fakeSMTP(t *testing.T) (host string, port int, sessions chan received) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	sessions = make(chan received, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() { sessions <- serveSMTP(conn) }()
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+
+	return addr.IP.String(), addr.Port, sessions
+}
+
+func nextSession(t *testing.T, sessions chan received) received {
+	t.Helper()
+
+	select {
+	case r := <-sessions:
+		return r
+	case <-time.After(5 * time.Second):
+		t.Fatal("no SMTP session")
+		return received{}
+	}
+}
+
+func serveSMTP(conn net.Conn) (r received) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 localhost ESMTP")
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250-localhost")
+			tp.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			credentials, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(arg, "PLAIN "))
+			r.auth = string(credentials)
+			tp.PrintfLine("235 2.7.0 Authentication successful")
+		case "MAIL":
+			r.from = arg
+			tp.PrintfLine("250 OK")
+		case "RCPT":
+			if strings.Contains(arg, "@reject.example.com") {
+				tp.PrintfLine("550 5.1.1 No such user")
+				continue
+			}
+			r.rcpt = append(r.rcpt, arg)
+			tp.PrintfLine("250 OK")
+		case "DATA":
+			tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			r.data = string(data)
+			tp.PrintfLine("250 OK queued")
+		case "QUIT":
+			tp.PrintfLine("221 Bye")
+			return
+		default:
+			tp.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTPMailer(t *testing.T) {
+	host, port, sessions := fakeSMTP(t)
+	m := NewSMTPMailer(host, port, "noreply@example.com", "secret", "noreply@example.com", "Project Name")
+	m.Timeout = 5 * time.Second
+
+	msg := Message{
+		To:      []string{"budi@example.com", "siti@example.com"},
+		Subject: "Reset Password - Project Name",
+		Text:    "Open https://example.com/reset?token=abc",
+		HTML:    `<a href="https://example.com/reset?token=abc">Reset</a>`,
+	}
+	if err := m.Send(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	r := nextSession(t, sessions)
+
+	if r.auth != "\x00noreply@example.com\x00secret" {
+		t.Errorf("auth = %q", r.auth)
+	}
+	if r.from != "FROM:<noreply@example.com>" {
+		t.Errorf("from = %q", r.from)
+	}
+	if strings.Join(r.rcpt, ",") != "TO:<budi@example.com>,TO:<siti@example.com>" {
+		t.Errorf("rcpt = %q", r.rcpt)
+	}
+
+	// ReadDotBytes turns the CRLFs of the DATA section into LFs
+	parsed, bodies := parts(t, []byte(strings.ReplaceAll(r.data, "\n", "\r\n")))
+	if got := parsed.Header.Get("From"); got != `"Project Name" <noreply@example.com>` {
+		t.Errorf("From = %q", got)
+	}
+	if got := parsed.Header.Get("To"); got != "budi@example.com, siti@example.com" {
+		t.Errorf("To = %q", got)
+	}
+	if got := parsed.Header.Get("Subject"); got != msg.Subject {
+		t.Errorf("Subject = %q", got)
+	}
+	if bodies["text/plain; charset=UTF-8"] != msg.Text || bodies["text/html; charset=UTF-8"] != msg.HTML {
+		t.Errorf("parts = %q", bodies)
+	}
+}
+
+func TestSMTPMailerErrors(t *testing.T) {
+	host, port, sessions := fakeSMTP(t)
+	m := NewSMTPMailer(host, port, "", "", "noreply@example.com", "")
+	m.Timeout = 5 * time.Second
+
+	err := m.Send(Message{To: []string{"budi@example.com", "x@reject.example.com"}, Subject: "Hi", Text: "Hi"})
+	if err == nil || !strings.Contains(err.Error(), "rcpt x@reject.example.com") {
+		t.Fatalf("refused recipient: %v", err)
+	}
+	if r := nextSession(t, sessions); r.auth != "" || r.data != "" {
+		t.Errorf("session = %+v", r)
+	}
+
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	_, closed, _ := net.SplitHostPort(listener.Addr().String())
+	listener.Close()
+
+	m.Port, _ = strconv.Atoi(closed)
+	if err := m.Send(Message{To: []string{"budi@example.com"}, Subject: "Hi", Text: "Hi"}); err == nil || !strings.Contains(err.Error(), "mailer: connect") {
+		t.Errorf("server down: %v", err)
+	}
+}
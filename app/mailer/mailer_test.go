@@ -0,0 +1,185 @@
+package mailer
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// parts reads the message and its multipart/alternative parts, NextPart decodes
+// the quoted-printable bodies
+func parts(t *testing.T, raw []byte) (*mail.Message, map[string]string) {
+	t.Helper()
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, %v", msg.Header.Get("Content-Type"), err)
+	}
+
+	bodies := map[string]string{}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(part)
+		bodies[part.Header.Get("Content-Type")] = string(body)
+	}
+
+	return msg, bodies
+}
+
+func TestMessageBuild(t *testing.T) {
+	long := strings.Repeat("a=b ", 40) // over the 76 character line limit
+	msg := Message{
+		To:      []string{"budi@example.com", "siti@example.com"},
+		Subject: "Verifikasi Alamat Email Anda – Ümlaut",
+		Text:    "Hello\n" + long,
+		HTML:    `<p>Hello</p><a href="https://example.com/?token=abc">Reset</a>`,
+	}
+
+	raw, err := msg.Build("noreply@example.com", "Project Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, bodies := parts(t, raw)
+
+	header := parsed.Header
+	if got := header.Get("From"); got != `"Project Name" <noreply@example.com>` {
+		t.Errorf("From = %q", got)
+	}
+	if got := header.Get("To"); got != "budi@example.com, siti@example.com" {
+		t.Errorf("To = %q", got)
+	}
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(header.Get("Subject")); subject != msg.Subject {
+		t.Errorf("Subject = %q, decoded %q", header.Get("Subject"), subject)
+	}
+	if !regexp.MustCompile(`^<[0-9a-f]{24}@example\.com>$`).MatchString(header.Get("Message-ID")) {
+		t.Errorf("Message-ID = %q", header.Get("Message-ID"))
+	}
+	if _, err := header.Date(); err != nil {
+		t.Errorf("Date = %q, %v", header.Get("Date"), err)
+	}
+	if header.Get("MIME-Version") != "1.0" {
+		t.Errorf("MIME-Version = %q", header.Get("MIME-Version"))
+	}
+
+	// text mode quoted-printable sends line breaks as CRLF
+	if got := bodies["text/plain; charset=UTF-8"]; got != strings.ReplaceAll(msg.Text, "\n", "\r\n") {
+		t.Errorf("text part = %q", got)
+	}
+	if got := bodies["text/html; charset=UTF-8"]; got != msg.HTML {
+		t.Errorf("html part = %q", got)
+	}
+	if n := strings.Count(string(raw), "Content-Transfer-Encoding: quoted-printable\r\n"); n != 2 {
+		t.Errorf("%d quoted-printable parts, want 2", n)
+	}
+	_, body, _ := strings.Cut(string(raw), "\r\n\r\n")
+	for _, line := range strings.Split(body, "\r\n") {
+		if len(line) > 78 {
+			t.Errorf("line over 78 characters: %q", line)
+		}
+	}
+}
+
+func TestMessageBuildSkipsEmptyParts(t *testing.T) {
+	raw, err := Message{To: []string{"budi@example.com"}, Subject: "Hi", Text: "plain only"}.Build("noreply@example.com", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, bodies := parts(t, raw)
+	if len(bodies) != 1 || bodies["text/plain; charset=UTF-8"] != "plain only" {
+		t.Errorf("parts = %q", bodies)
+	}
+
+	if _, err := (Message{Subject: "Hi", Text: "nobody"}).Build("noreply@example.com", ""); err == nil {
+		t.Error("message without recipient was built")
+	}
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox") // created on the first send
+	m := NewFileMailer(dir)
+
+	msg := Message{To: []string{"budi/../x@example.com"}, Subject: "Reset Password", Text: "token abc", HTML: "<p>token abc</p>"}
+	if err := m.Send(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("entries = %v, %v", entries, err)
+	}
+
+	name := entries[0].Name()
+	if !regexp.MustCompile(`^\d{8}T\d{6}\.\d{9}-0001-budi_\.\._x_at_example\.com\.eml$`).MatchString(name) {
+		t.Errorf("name = %q", name)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, bodies := parts(t, raw)
+	if parsed.Header.Get("From") != "<outbox@localhost>" || parsed.Header.Get("Subject") != "Reset Password" {
+		t.Errorf("header = %v", parsed.Header)
+	}
+	if bodies["text/plain; charset=UTF-8"] != "token abc" || bodies["text/html; charset=UTF-8"] != "<p>token abc</p>" {
+		t.Errorf("parts = %q", bodies)
+	}
+
+	if err := m.Send(Message{Subject: "nobody"}); err == nil {
+		t.Error("message without recipient was written")
+	}
+}
+
+func TestFileMailerConcurrentSends(t *testing.T) {
+	dir := t.TempDir()
+	m := NewFileMailer(dir)
+
+	const sends = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, sends)
+	for i := 0; i < sends; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- m.Send(Message{To: []string{"budi@example.com"}, Subject: "Hi", Text: "Hi"})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the sequence number keeps files written in the same instant apart
+	entries, _ := os.ReadDir(dir)
+	seqs := map[string]bool{}
+	for _, entry := range entries {
+		seqs[strings.Split(entry.Name(), "-")[1]] = true
+	}
+	if len(entries) != sends || len(seqs) != sends {
+		t.Errorf("%d files with %d sequence numbers, want %d", len(entries), len(seqs), sends)
+	}
+}
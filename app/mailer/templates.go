@@ -0,0 +1,114 @@
+package mailer
+
+import (
+	"bytes"
+	"html"
+	"html/template"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	strip "github.com/grokify/html-strip-tags-go"
+)
+
+// TemplateDir holds the bundled HTML email templates
+var TemplateDir = filepath.Join("assets", "html")
+
+// ForgotPasswordData fills forgot-password.html. ID is the reset token placed in the link.
+type ForgotPasswordData struct {
+	AppName      string
+	ContactEmail string
+	FrontendUrl  string
+	Fullname     string
+	ID           string
+}
+
+// EmailVerificationData fills email-verification.html. ID is the verification token placed in the link.
+type EmailVerificationData struct {
+	AppName      string
+	ContactEmail string
+	FrontendUrl  string
+	Fullname     string
+	ID           string
+}
+
+// NewUserData fills new-user.html
+type NewUserData struct {
+	Name  string
+	Email string
+}
+
+// EmailHelperData fills email-helper.html
+type EmailHelperData struct {
+	Fullname string
+	Email    string
+	Phone    string
+	Content  string
+}
+
+func ForgotPassword(to string, data ForgotPasswordData) (Message, error) {
+	return render(to, "Reset Password - "+data.AppName, "forgot-password.html", data)
+}
+
+func EmailVerification(to string, data EmailVerificationData) (Message, error) {
+	return render(to, "Verifikasi Alamat Email Anda - "+data.AppName, "email-verification.html", data)
+}
+
+func NewUser(to string, data NewUserData) (Message, error) {
+	return render(to, "Pengguna Baru - "+data.Name, "new-user.html", data)
+}
+
+func EmailHelper(to, subject string, data EmailHelperData) (Message, error) {
+	return render(to, subject, "email-helper.html", data)
+}
+
+func render(to, subject, name string, data interface{}) (msg Message, err error) {
+	tmpl, err := template.ParseFiles(filepath.Join(TemplateDir, name))
+	if err != nil {
+		return
+	}
+
+	var body bytes.Buffer
+	if err = tmpl.Execute(&body, data); err != nil {
+		return
+	}
+
+	msg = Message{
+		To:      []string{to},
+		Subject: subject,
+		HTML:    body.String(),
+		Text:    htmlToText(body.String()),
+	}
+
+	return
+}
+
+var (
+	styleBlock  = regexp.MustCompile(`(?is)<!DOCTYPE[^>]*>|<(style|title|head)[^>]*>.*?</(style|title|head)\s*>`)
+	anchorTag   = regexp.MustCompile(`(?is)<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a\s*>`)
+	blockTag    = regexp.MustCompile(`(?i)</?(tr|p|div|br|h[1-6]|li|table)[^>]*>`)
+	blankSpaces = regexp.MustCompile(`[ \t]+`)
+	blankLines  = regexp.MustCompile(`\n\s*\n+`)
+)
+
+// htmlToText makes the plain text alternative, keeping link targets visible
+func htmlToText(s string) string {
+	s = styleBlock.ReplaceAllString(s, "")
+	s = anchorTag.ReplaceAllStringFunc(s, func(a string) string {
+		m := anchorTag.FindStringSubmatch(a)
+		label := strings.TrimSpace(strip.StripTags(m[2]))
+		if label == "" || label == m[1] {
+			return m[1]
+		}
+		return label + " (" + m[1] + ")"
+	})
+	s = blockTag.ReplaceAllString(s, "\n")
+	s = html.UnescapeString(strip.StripTags(s))
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(blankSpaces.ReplaceAllString(line, " "))
+	}
+
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
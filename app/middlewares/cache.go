@@ -0,0 +1,28 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CacheControl lets clients and shared caches keep successful GET responses for
+// maxAge seconds, and serve them stale for a day while revalidating. Errors are
+// not cached.
+func CacheControl(maxAge int) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			res := c.Response()
+			res.Before(func() {
+				if c.Request().Method == http.MethodGet && res.Status < 300 {
+					res.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d, stale-while-revalidate=86400", maxAge))
+				} else {
+					res.Header().Set("Cache-Control", "no-store")
+				}
+			})
+
+			return next(c)
+		}
+	}
+}
@@ -9,6 +9,12 @@ import (
 func Cors() echo.MiddlewareFunc {
 	return middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"*"},
-		AllowMethods: []string{echo.HEAD, echo.GET, echo.PUT, echo.POST, echo.DELETE},
+		AllowMethods: []string{echo.HEAD, echo.GET, echo.PUT, echo.PATCH, echo.POST, echo.DELETE},
+		// browsers only let scripts read the tus headers when they are listed
+		ExposeHeaders: []string{
+			"Location", "Tus-Resumable", "Tus-Version", "Tus-Extension", "Tus-Max-Size",
+			"Upload-Offset", "Upload-Length", "Upload-Expires", "Upload-Metadata",
+			"Upload-File-Id", "Upload-File-Key",
+		},
 	})
 }
@@ -11,7 +11,10 @@ import (
 func Gzip() echo.MiddlewareFunc {
 	return middleware.GzipWithConfig(middleware.GzipConfig{
 		Skipper: func(c echo.Context) bool {
-			return strings.Contains(c.Path(), "/docs")
+			// downloads answer Range requests, which compressing would break
+			return strings.Contains(c.Path(), "/docs") ||
+				strings.HasSuffix(c.Path(), "/download") ||
+				strings.HasPrefix(c.Path(), "/storage/")
 
 		},
 	})
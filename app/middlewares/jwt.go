@@ -1,47 +1,44 @@
 package middlewares
 
 import (
-	"encoding/base64"
+	"crypto/rand"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"net/http"
 	"project-name/app/models"
+	"project-name/app/session"
 	"project-name/app/utils"
 	"project-name/config"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 )
 
-func Auth() echo.MiddlewareFunc {
+// JwtClaims is the payload of an access token
+type JwtClaims struct {
+	UserID    int    `json:"uid"`
+	RoleID    int    `json:"role"`
+	SessionID string `json:"sid"`
+	jwt.StandardClaims
+}
+
+// Auth accepts requests with a valid access token whose session is still active in sessions
+func Auth(sessions session.Store) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 
 			authorizationHeader := c.Request().Header.Get("Authorization")
 			bearerToken := strings.Split(authorizationHeader, " ")
-			if len(bearerToken) != 2 {
+			if len(bearerToken) != 2 || !strings.EqualFold(bearerToken[0], "Bearer") {
 				return c.JSON(http.StatusUnauthorized, utils.NewUnauthorizedError("Incorrect Authorization Token"))
 			}
 
 			tokenStr := bearerToken[1]
 
-			// // Periksa apakah header Authorization kosong
-			// if authorizationHeader == "" {
-			// 	return c.JSON(http.StatusUnauthorized, utils.NewUnauthorizedError("Authorization header missing"))
-			// }
-
-			// // Periksa apakah token dimulai dengan 'Bearer '
-			// if !strings.HasPrefix(authorizationHeader, "Bearer ") {
-			// 	return c.JSON(http.StatusUnauthorized, utils.NewUnauthorizedError("Invalid authorization scheme"))
-			// }
-
-			// // Ambil token setelah prefix 'Bearer '
-			// tokenStr := strings.TrimPrefix(authorizationHeader, "Bearer ")
-
-			UserID, err := ValidateToken(tokenStr)
+			claims, err := ValidateToken(tokenStr)
 			if err != nil {
 				fmt.Println("Token Validation,", err)
 				return c.JSON(
@@ -49,7 +46,17 @@ func Auth() echo.MiddlewareFunc {
 					utils.NewUnauthorizedError(err.Error()),
 				)
 			}
-			c.Set("user_id", UserID)
+			if _, err := session.Validate(c.Request().Context(), sessions, claims.SessionID, claims.UserID, c.RealIP()); err != nil {
+				return c.JSON(
+					http.StatusUnauthorized,
+					utils.NewUnauthorizedError(err.Error()),
+				)
+			}
+
+			c.Set("user_id", claims.UserID)
+			c.Set("role_id", claims.RoleID)
+			c.Set("token_id", claims.Id)
+			c.Set("session_id", claims.SessionID)
 			return next(c)
 		}
 	}
@@ -77,54 +84,83 @@ func CheckAPIKey() echo.MiddlewareFunc {
 	}
 }
 
-func ValidateToken(tokenString string) (userID int, err error) {
-	location, err := time.LoadLocation("Asia/Jakarta")
+// ValidateToken verifies the signature of an access token against the key named
+// by its "kid" header and checks its claims. Malformed tokens and bad signatures
+// are reported as utils.ErrInvalidJWTToken, expired or otherwise invalid claims
+// as utils.ErrInvalidJWTClaims.
+func ValidateToken(tokenString string) (claims *JwtClaims, err error) {
+	keys, err := loadKeySet()
 	if err != nil {
-		location = time.Local
-		err = nil
-		// fmt.Println("Failed to get Asia/Jakarta time when validating token. Error: ", err)
+		return nil, fmt.Errorf("%w: %v", utils.ErrInvalidJWTToken, err)
 	}
-	tokenStringbyt, err := hex.DecodeString(tokenString)
+
+	claims = &JwtClaims{}
+	_, err = jwt.ParseWithClaims(tokenString, claims, keys.lookup)
 	if err != nil {
-		err = errors.New("incorrect token format")
-		return
+		return nil, classifyTokenError(err)
+	}
+
+	if claims.UserID == 0 || claims.SessionID == "" || claims.Id == "" || claims.ExpiresAt == 0 {
+		return nil, fmt.Errorf("%w: missing required claims", utils.ErrInvalidJWTClaims)
 	}
-	str := string(tokenStringbyt)
-	newtStr := strings.Replace(string(str), config.LoadConfig().AppKey, "", -1)
-	decoded, err := base64.StdEncoding.DecodeString(newtStr)
+
+	return claims, nil
+}
+
+func classifyTokenError(err error) error {
+	ve, ok := err.(*jwt.ValidationError)
+	if !ok {
+		return fmt.Errorf("%w: %v", utils.ErrInvalidJWTToken, err)
+	}
+
+	switch {
+	case ve.Errors&jwt.ValidationErrorMalformed != 0:
+		return fmt.Errorf("%w: token is malformed", utils.ErrInvalidJWTToken)
+	case ve.Errors&(jwt.ValidationErrorUnverifiable|jwt.ValidationErrorSignatureInvalid) != 0:
+		return fmt.Errorf("%w: %v", utils.ErrInvalidJWTToken, ve)
+	case ve.Errors&jwt.ValidationErrorExpired != 0:
+		return fmt.Errorf("%w: token is expired", utils.ErrInvalidJWTClaims)
+	default:
+		return fmt.Errorf("%w: %v", utils.ErrInvalidJWTClaims, ve)
+	}
+}
+
+// AuthMakeToken signs a new access token for the user's session with the active key
+func AuthMakeToken(user models.User, sessionID string) (string, error) {
+	keys, err := loadKeySet()
 	if err != nil {
-		err = errors.New("incorrect token format")
-		return
+		return "", err
 	}
-	newStr := strings.Replace(string(decoded), config.LoadConfig().AppKey, "", -1)
-	newdecoded, err := base64.StdEncoding.DecodeString(newStr)
+
+	tokenID, err := newTokenID()
 	if err != nil {
-		err = errors.New("incorrect token format")
-		return
+		return "", err
 	}
-	parts := strings.Split(string(newdecoded), "&")
-	expiredAt, _ := strconv.Atoi(parts[1])
-	if expiredAt < int(time.Now().In(location).Unix()) {
-		err = errors.New("incorrect token format")
-		return
+
+	now := time.Now()
+	claims := JwtClaims{
+		UserID:    int(user.ID),
+		RoleID:    user.RoleID,
+		SessionID: sessionID,
+		StandardClaims: jwt.StandardClaims{
+			Id:        tokenID,
+			Issuer:    config.LoadConfig().AppName,
+			Subject:   strconv.Itoa(int(user.ID)),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(time.Duration(config.LoadConfig().JwtAccessTokenTTL) * time.Minute).Unix(),
+		},
 	}
-	userID, _ = strconv.Atoi(parts[0])
 
-	return
+	token := jwt.NewWithClaims(keys.active.Method, claims)
+	token.Header["kid"] = keys.active.ID
+
+	return token.SignedString(keys.active.SignKey)
 }
 
-func AuthMakeToken(user models.User) (string, error) {
-	location, err := time.LoadLocation("Asia/Jakarta")
-	if err != nil {
-		location = time.Local
-		err = nil
-		// fmt.Println("Failed to get Asia/Jakarta time when making token in authentification. Error: ", err)
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
-
-	ExpiresAt := time.Now().In(location).AddDate(0, 0, 1).Unix()
-	str := fmt.Sprintf("%v&%v", user.ID, ExpiresAt)
-	encoded := base64.StdEncoding.EncodeToString([]byte(str)) + config.LoadConfig().AppKey
-	token := base64.StdEncoding.EncodeToString([]byte(encoded)) + config.LoadConfig().AppKey
-	token = hex.EncodeToString([]byte(token))
-	return token, nil
+	return hex.EncodeToString(b), nil
 }
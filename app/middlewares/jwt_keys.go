@@ -0,0 +1,189 @@
+package middlewares
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+	"project-name/config"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// jwtKey is a single entry of the key set, identified by the "kid" header
+type jwtKey struct {
+	ID        string
+	Method    jwt.SigningMethod
+	SignKey   interface{}
+	VerifyKey interface{}
+}
+
+type jwtKeySet struct {
+	active *jwtKey
+	keys   map[string]*jwtKey
+}
+
+var (
+	keySet     *jwtKeySet
+	keySetErr  error
+	keySetOnce sync.Once
+)
+
+// loadKeySet builds the signing key set once from config. The active key signs
+// new tokens, retired keys are only kept to verify tokens issued before a rotation.
+func loadKeySet() (*jwtKeySet, error) {
+	keySetOnce.Do(func() {
+		cfg := config.LoadConfig()
+
+		set := &jwtKeySet{keys: map[string]*jwtKey{}}
+
+		active, err := buildSigningKey(cfg.JwtKeyID, cfg.JwtSigningMethod, cfg.JwtSecret, cfg.JwtPrivateKeyPath)
+		if err != nil {
+			keySetErr = err
+			return
+		}
+		set.active = active
+		set.keys[active.ID] = active
+
+		for _, entry := range strings.Split(cfg.JwtRetiredKeys, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			retired, err := buildRetiredKey(entry)
+			if err != nil {
+				keySetErr = err
+				return
+			}
+			if _, exists := set.keys[retired.ID]; exists {
+				keySetErr = fmt.Errorf("duplicate jwt key id %q", retired.ID)
+				return
+			}
+			set.keys[retired.ID] = retired
+		}
+
+		keySet = set
+	})
+
+	return keySet, keySetErr
+}
+
+func buildSigningKey(kid, alg, secret, privateKeyPath string) (*jwtKey, error) {
+	method := jwt.GetSigningMethod(alg)
+	if method == nil {
+		return nil, fmt.Errorf("unsupported jwt signing method %q", alg)
+	}
+
+	key := &jwtKey{ID: kid, Method: method}
+
+	if _, ok := method.(*jwt.SigningMethodHMAC); ok {
+		if secret == "" {
+			return nil, errors.New("jwt secret is empty")
+		}
+		key.SignKey = []byte(secret)
+		key.VerifyKey = []byte(secret)
+		return key, nil
+	}
+
+	pem, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("read jwt private key: %w", err)
+	}
+
+	switch method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		private, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, err
+		}
+		key.SignKey, key.VerifyKey = private, &private.PublicKey
+	case *jwt.SigningMethodECDSA:
+		private, err := jwt.ParseECPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, err
+		}
+		key.SignKey, key.VerifyKey = private, &private.PublicKey
+	case *jwt.SigningMethodEd25519:
+		private, err := jwt.ParseEdPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := private.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("jwt ed25519 key is not a signer")
+		}
+		key.SignKey, key.VerifyKey = private, signer.Public()
+	default:
+		return nil, fmt.Errorf("unsupported jwt signing method %q", alg)
+	}
+
+	return key, nil
+}
+
+// buildRetiredKey parses "kid=ALG:value" where value is the HMAC secret for
+// HS* algorithms or the path of a PEM public key for asymmetric ones.
+func buildRetiredKey(entry string) (*jwtKey, error) {
+	kid, spec, ok := strings.Cut(entry, "=")
+	if !ok {
+		return nil, fmt.Errorf("invalid jwt retired key %q", entry)
+	}
+	alg, value, ok := strings.Cut(spec, ":")
+	if !ok || kid == "" || value == "" {
+		return nil, fmt.Errorf("invalid jwt retired key %q", entry)
+	}
+
+	method := jwt.GetSigningMethod(strings.ToUpper(alg))
+	if method == nil {
+		return nil, fmt.Errorf("unsupported jwt signing method %q", alg)
+	}
+
+	key := &jwtKey{ID: kid, Method: method}
+
+	if _, ok := method.(*jwt.SigningMethodHMAC); ok {
+		key.VerifyKey = []byte(value)
+		return key, nil
+	}
+
+	pem, err := os.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("read jwt public key: %w", err)
+	}
+
+	switch method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		key.VerifyKey, err = jwt.ParseRSAPublicKeyFromPEM(pem)
+	case *jwt.SigningMethodECDSA:
+		key.VerifyKey, err = jwt.ParseECPublicKeyFromPEM(pem)
+	case *jwt.SigningMethodEd25519:
+		key.VerifyKey, err = jwt.ParseEdPublicKeyFromPEM(pem)
+	default:
+		err = fmt.Errorf("unsupported jwt signing method %q", alg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// lookup resolves the verification key for a parsed token and makes sure the
+// token's alg matches the key, so an RSA public key can never be used as an HMAC secret.
+func (s *jwtKeySet) lookup(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("token has no key id")
+	}
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+
+	return key.VerifyKey, nil
+}
@@ -0,0 +1,227 @@
+package middlewares
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"project-name/app/models"
+	"project-name/app/utils"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// useKeySet makes set the key set of this test instead of the one from config
+func useKeySet(t *testing.T, set *jwtKeySet) {
+	t.Helper()
+
+	keySetOnce.Do(func() {})
+	previous, previousErr := keySet, keySetErr
+	keySet, keySetErr = set, nil
+	t.Cleanup(func() { keySet, keySetErr = previous, previousErr })
+}
+
+// testKeySet signs with the HMAC key "current" and also accepts the retired HMAC
+// key "old" and the RSA key "rsa"
+func testKeySet(t *testing.T) (*jwtKeySet, *rsa.PrivateKey) {
+	t.Helper()
+
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current := &jwtKey{ID: "current", Method: jwt.SigningMethodHS256, SignKey: []byte("current-secret"), VerifyKey: []byte("current-secret")}
+	set := &jwtKeySet{
+		active: current,
+		keys: map[string]*jwtKey{
+			"current": current,
+			"old":     {ID: "old", Method: jwt.SigningMethodHS256, VerifyKey: []byte("old-secret")},
+			"rsa":     {ID: "rsa", Method: jwt.SigningMethodRS256, VerifyKey: &private.PublicKey},
+		},
+	}
+
+	return set, private
+}
+
+func validClaims() JwtClaims {
+	return JwtClaims{
+		UserID:    7,
+		RoleID:    3,
+		SessionID: "session",
+		StandardClaims: jwt.StandardClaims{
+			Id:        "token",
+			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: time.Now().Add(time.Minute).Unix(),
+		},
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims JwtClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed
+}
+
+func TestValidateToken(t *testing.T) {
+	set, private := testKeySet(t)
+	useKeySet(t, set)
+
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: mustMarshalPublic(t, &private.PublicKey)})
+
+	expired := validClaims()
+	expired.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+	notYet := validClaims()
+	notYet.NotBefore = time.Now().Add(time.Hour).Unix()
+	noSession := validClaims()
+	noSession.SessionID = ""
+	noExpiry := validClaims()
+	noExpiry.ExpiresAt = 0
+
+	valid := sign(t, jwt.SigningMethodHS256, "current", []byte("current-secret"), validClaims())
+	parts := strings.Split(valid, ".")
+	other := strings.Split(sign(t, jwt.SigningMethodHS256, "current", []byte("current-secret"), noSession), ".")
+
+	tests := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"active key", valid, nil},
+		{"retired key", sign(t, jwt.SigningMethodHS256, "old", []byte("old-secret"), validClaims()), nil},
+		{"asymmetric key", sign(t, jwt.SigningMethodRS256, "rsa", private, validClaims()), nil},
+
+		{"no key id", sign(t, jwt.SigningMethodHS256, "", []byte("current-secret"), validClaims()), utils.ErrInvalidJWTToken},
+		{"unknown key id", sign(t, jwt.SigningMethodHS256, "gone", []byte("current-secret"), validClaims()), utils.ErrInvalidJWTToken},
+		{"wrong secret", sign(t, jwt.SigningMethodHS256, "current", []byte("guessed"), validClaims()), utils.ErrInvalidJWTToken},
+		{"key of another id", sign(t, jwt.SigningMethodHS256, "current", []byte("old-secret"), validClaims()), utils.ErrInvalidJWTToken},
+		{"public key as hmac secret", sign(t, jwt.SigningMethodHS256, "rsa", publicPEM, validClaims()), utils.ErrInvalidJWTToken},
+		{"other alg of the same family", sign(t, jwt.SigningMethodHS512, "current", []byte("current-secret"), validClaims()), utils.ErrInvalidJWTToken},
+		{"alg none", sign(t, jwt.SigningMethodNone, "current", jwt.UnsafeAllowNoneSignatureType, validClaims()), utils.ErrInvalidJWTToken},
+		{"payload swapped", parts[0] + "." + other[1] + "." + parts[2], utils.ErrInvalidJWTToken},
+		{"malformed", "not-a-token", utils.ErrInvalidJWTToken},
+
+		{"expired", sign(t, jwt.SigningMethodHS256, "current", []byte("current-secret"), expired), utils.ErrInvalidJWTClaims},
+		{"not valid yet", sign(t, jwt.SigningMethodHS256, "current", []byte("current-secret"), notYet), utils.ErrInvalidJWTClaims},
+		{"missing session", sign(t, jwt.SigningMethodHS256, "current", []byte("current-secret"), noSession), utils.ErrInvalidJWTClaims},
+		{"missing expiry", sign(t, jwt.SigningMethodHS256, "current", []byte("current-secret"), noExpiry), utils.ErrInvalidJWTClaims},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := ValidateToken(tt.token)
+			if tt.err == nil {
+				if err != nil {
+					t.Fatalf("rejected: %v", err)
+				}
+				if claims.UserID != 7 || claims.SessionID != "session" {
+					t.Errorf("claims = %+v", claims)
+				}
+				return
+			}
+
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			// the two kinds never overlap, Auth reports them differently
+			if errors.Is(err, utils.ErrInvalidJWTToken) && errors.Is(err, utils.ErrInvalidJWTClaims) {
+				t.Errorf("err = %v is both kinds", err)
+			}
+		})
+	}
+}
+
+func TestAuthMakeToken(t *testing.T) {
+	set, _ := testKeySet(t)
+	useKeySet(t, set)
+
+	user := models.User{RoleID: 2}
+	user.ID = 7
+	signed, err := AuthMakeToken(user, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, _, err := new(jwt.Parser).ParseUnverified(signed, &JwtClaims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Header["kid"] != "current" || token.Method.Alg() != "HS256" {
+		t.Errorf("header = %v", token.Header)
+	}
+
+	claims, err := ValidateToken(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.UserID != 7 || claims.RoleID != 2 || claims.SessionID != "session" || claims.Id == "" || claims.Subject != "7" {
+		t.Errorf("claims = %+v", claims)
+	}
+}
+
+func TestBuildRetiredKey(t *testing.T) {
+	_, private := testKeySet(t)
+	publicPath := filepath.Join(t.TempDir(), "public.pem")
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: mustMarshalPublic(t, &private.PublicKey)})
+	if err := os.WriteFile(publicPath, publicPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		entry string
+		alg   string
+		valid bool
+	}{
+		{"old=HS256:old-secret", "HS256", true},
+		{"old=hs512:old-secret", "HS512", true},
+		{"rsa=RS256:" + publicPath, "RS256", true},
+		{"old", "", false},
+		{"old=HS256", "", false},
+		{"=HS256:secret", "", false},
+		{"old=XX256:secret", "", false},
+		{"rsa=RS256:" + publicPath + ".missing", "", false},
+		{"rsa=ES256:" + publicPath, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.entry, func(t *testing.T) {
+			key, err := buildRetiredKey(tt.entry)
+			if !tt.valid {
+				if err == nil {
+					t.Fatalf("accepted %+v", key)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if key.Method.Alg() != tt.alg || key.SignKey != nil {
+				t.Errorf("key = %+v", key)
+			}
+		})
+	}
+}
+
+func mustMarshalPublic(t *testing.T, public *rsa.PublicKey) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return der
+}
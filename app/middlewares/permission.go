@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"project-name/app/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PermissionChecker looks up the user's current role, not the role in the token,
+// so a role change takes effect without signing in again. It is implemented by
+// repository.RoleRepository.
+type PermissionChecker interface {
+	UserHasPermission(ctx context.Context, userID int, permission string) bool
+}
+
+// Authorizer guards routes by permission
+type Authorizer struct {
+	checker PermissionChecker
+}
+
+func NewAuthorizer(checker PermissionChecker) *Authorizer {
+	return &Authorizer{checker: checker}
+}
+
+// RequirePermission allows the request only when the authenticated user's role
+// grants every listed permission. It must run after Auth().
+func (a *Authorizer) RequirePermission(permissions ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, ok := c.Get("user_id").(int)
+			if !ok {
+				return c.JSON(http.StatusUnauthorized, utils.NewUnauthorizedError("Incorrect Authorization Token"))
+			}
+
+			for _, permission := range permissions {
+				if !a.checker.UserHasPermission(c.Request().Context(), userID, permission) {
+					return c.JSON(http.StatusForbidden, utils.NewForbiddenError("Missing permission "+permission))
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
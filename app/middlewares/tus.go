@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TusVersion is the version of the tus resumable upload protocol served
+const TusVersion = "1.0.0"
+
+// TusResumable adds the tus protocol headers to every response and refuses
+// requests speaking another version of the protocol. maxSize is the largest
+// Upload-Length accepted.
+func TusResumable(maxSize int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Response().Header()
+			header.Set("Tus-Resumable", TusVersion)
+			header.Set("Tus-Version", TusVersion)
+			header.Set("Tus-Extension", "creation,expiration,termination")
+			header.Set("Tus-Max-Size", strconv.FormatInt(maxSize, 10))
+
+			if c.Request().Header.Get("Tus-Resumable") != TusVersion {
+				return c.NoContent(http.StatusPreconditionFailed)
+			}
+
+			return next(c)
+		}
+	}
+}
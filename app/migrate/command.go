@@ -0,0 +1,147 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const usage = `usage: migrate <command>
+
+commands:
+  up           apply all pending migrations
+  down [n]     revert the last n applied migrations (default 1)
+  status       list migrations and whether they are applied
+  create NAME  write empty up and down files for a new migration`
+
+var nonWord = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Command runs the migrate subcommand in args. connect is only called by the
+// subcommands that need the database; create writes its files to dir.
+func Command(args []string, fsys fs.FS, dir string, connect func() *gorm.DB, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	if args[0] == "create" {
+		if len(args) != 2 {
+			return errors.New("usage: migrate create NAME")
+		}
+
+		up, down, err := Create(dir, args[1], time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "Created", up)
+		fmt.Fprintln(out, "Created", down)
+
+		return nil
+	}
+
+	migrator, err := New(connect(), fsys)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Fprintf(out, "Applied %s_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintln(out, "Nothing to migrate")
+		}
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			fmt.Fprintf(out, "Reverted %s_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(reverted) == 0 {
+			fmt.Fprintln(out, "Nothing to revert")
+		}
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			if status.Missing {
+				appliedAt += " (files missing)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+
+		return w.Flush()
+
+	default:
+		return errors.New(usage)
+	}
+
+	return nil
+}
+
+// Create writes empty up and down files for a new migration versioned at now
+func Create(dir, name string, now time.Time) (up, down string, err error) {
+	name = strings.Trim(nonWord.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		err = errors.New("migration name is required")
+		return
+	}
+
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+
+	base := filepath.Join(dir, now.UTC().Format(versionLayout)+"_"+name)
+	up, down = base+".up.sql", base+".down.sql"
+
+	for _, file := range []string{up, down} {
+		if _, statErr := os.Stat(file); statErr == nil {
+			err = fmt.Errorf("%s already exists", file)
+			return
+		}
+	}
+
+	if err = os.WriteFile(up, []byte("-- "+name+"\n"), 0644); err != nil {
+		return
+	}
+	err = os.WriteFile(down, []byte("-- revert "+name+"\n"), 0644)
+
+	return
+}
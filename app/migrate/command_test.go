@@ -0,0 +1,81 @@
+package migrate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestCreate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "migrations")
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.FixedZone("WIB", 7*60*60))
+
+	up, down, err := Create(dir, "Add user-avatar Column!", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// versions are UTC so that files created in different zones sort correctly
+	base := filepath.Join(dir, "20260303220607_add_user_avatar_column")
+	if up != base+".up.sql" || down != base+".down.sql" {
+		t.Errorf("files = %s, %s", up, down)
+	}
+	for _, name := range []string{up, down} {
+		if _, err := os.Stat(name); err != nil {
+			t.Error(err)
+		}
+	}
+
+	migrations, err := Load(os.DirFS(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 1 || migrations[0].Name != "add_user_avatar_column" {
+		t.Errorf("created files do not load: %+v", migrations)
+	}
+
+	if _, _, err := Create(dir, "add user avatar column", now); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("second create: err = %v", err)
+	}
+	if _, _, err := Create(dir, " -- ", now); err == nil {
+		t.Error("created a migration without a name")
+	}
+}
+
+func TestCommandArgs(t *testing.T) {
+	connect := func() *gorm.DB {
+		t.Fatal("connected to the database")
+		return nil
+	}
+
+	tests := []struct {
+		args  []string
+		error string
+	}{
+		{nil, "usage: migrate <command>"},
+		{[]string{"create"}, "usage: migrate create NAME"},
+		{[]string{"create", "a", "b"}, "usage: migrate create NAME"},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			err := Command(tt.args, testMigrations, t.TempDir(), connect, &bytes.Buffer{})
+			if err == nil || !strings.Contains(err.Error(), tt.error) {
+				t.Errorf("err = %v, want %q", err, tt.error)
+			}
+		})
+	}
+
+	var out bytes.Buffer
+	dir := t.TempDir()
+	if err := Command([]string{"create", "add_index"}, testMigrations, dir, connect, &out); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(out.String(), "Created "+dir) != 2 {
+		t.Errorf("output = %q", out.String())
+	}
+}
@@ -0,0 +1,288 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// lockKey identifies the Postgres advisory lock held while migrating, so only one
+// instance changes the schema at a time
+const lockKey int64 = 7_270_031_601
+
+// versionLayout is the timestamp format of migration versions
+const versionLayout = "20060102150405"
+
+var fileName = regexp.MustCompile(`^(\d{14})_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+var ErrNoDownMigration = errors.New("migration has no down file")
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version string
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is a migration with the time it was applied, if it was
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+	// Missing marks a version recorded in the database whose files are gone
+	Missing bool
+}
+
+type schemaMigration struct {
+	Version   string
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator applies and reverts migrations against a database
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// New reads the migrations in fsys and returns a Migrator for db
+func New(db *gorm.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load reads the migration files at the root of fsys, ordered by version
+func Load(fsys fs.FS) (migrations []Migration, err error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return
+	}
+
+	byVersion := map[string]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			err = fmt.Errorf("invalid migration file name %q", entry.Name())
+			return
+		}
+
+		var content []byte
+		content, err = fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return
+		}
+
+		version, name, direction := match[1], match[2], match[3]
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if migration.Name != name {
+			err = fmt.Errorf("migration %s has files with different names: %s and %s", version, migration.Name, name)
+			return
+		}
+
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			err = fmt.Errorf("migration %s_%s has no up file", migration.Version, migration.Name)
+			return
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return
+}
+
+// Up applies every pending migration in version order, each in its own transaction
+func (m *Migrator) Up(ctx context.Context) (applied []Migration, err error) {
+	err = m.locked(ctx, func(conn *gorm.DB) error {
+		done, err := appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Up).Error; err != nil {
+					return err
+				}
+
+				return tx.Create(&schemaMigration{
+					Version:   migration.Version,
+					Name:      migration.Name,
+					AppliedAt: time.Now(),
+				}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %s_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return
+}
+
+// Down reverts the last steps applied migrations, newest first
+func (m *Migrator) Down(ctx context.Context, steps int) (reverted []Migration, err error) {
+	files := map[string]Migration{}
+	for _, migration := range m.migrations {
+		files[migration.Version] = migration
+	}
+
+	err = m.locked(ctx, func(conn *gorm.DB) error {
+		var rows []schemaMigration
+		if err := conn.Order("version DESC").Limit(steps).Find(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			migration, ok := files[row.Version]
+			if !ok {
+				return fmt.Errorf("migration %s_%s is applied but its files are missing", row.Version, row.Name)
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %s_%s: %w", migration.Version, migration.Name, ErrNoDownMigration)
+			}
+
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return err
+				}
+
+				return tx.Where("version = ?", migration.Version).Delete(&schemaMigration{}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %s_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return
+}
+
+// Status lists every known migration, and applied versions without files, in version order
+func (m *Migrator) Status(ctx context.Context) (statuses []Status, err error) {
+	done, err := appliedVersions(m.db.WithContext(ctx))
+	if err != nil {
+		return
+	}
+
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if row, ok := done[migration.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.AppliedAt = &appliedAt
+			delete(done, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+
+	for _, row := range done {
+		appliedAt := row.AppliedAt
+		statuses = append(statuses, Status{
+			Migration: Migration{Version: row.Version, Name: row.Name},
+			AppliedAt: &appliedAt,
+			Missing:   true,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return
+}
+
+// Pending returns the migrations not applied yet
+func (m *Migrator) Pending(ctx context.Context) (pending []Migration, err error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, status.Migration)
+		}
+	}
+
+	return
+}
+
+// locked runs fn on a single connection holding the migration advisory lock,
+// creating the schema_migrations table first
+func (m *Migrator) locked(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", lockKey).Error; err != nil {
+			return err
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", lockKey)
+
+		err := conn.Exec(`CREATE TABLE IF NOT EXISTS "schema_migrations" (
+			"version" varchar(14) PRIMARY KEY,
+			"name" varchar(255) NOT NULL,
+			"applied_at" timestamptz NOT NULL
+		)`).Error
+		if err != nil {
+			return err
+		}
+
+		return fn(conn)
+	})
+}
+
+// appliedVersions returns the recorded migrations by version, none when the table
+// does not exist yet
+func appliedVersions(db *gorm.DB) (done map[string]schemaMigration, err error) {
+	done = map[string]schemaMigration{}
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		return
+	}
+
+	var rows []schemaMigration
+	if err = db.Find(&rows).Error; err != nil {
+		return
+	}
+	for _, row := range rows {
+		done[row.Version] = row
+	}
+
+	return
+}
@@ -0,0 +1,272 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"project-name/app/database/databasetest"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func file(content string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(content)}
+}
+
+func versions(migrations []Migration) (out []string) {
+	for _, migration := range migrations {
+		out = append(out, migration.Version+"_"+migration.Name)
+	}
+	return
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"20260102000000_second.up.sql":   file("CREATE TABLE second ()"),
+		"20260101000000_first.down.sql":  file("DROP TABLE first"),
+		"20260101000000_first.up.sql":    file("CREATE TABLE first ()"),
+		"20260110000000_up_only.up.sql":  file("SELECT 1"),
+		"20260102000000_second.down.sql": file("DROP TABLE second"),
+		"README.md":                      file("not a migration"),
+		"drafts/20260201000000_x.up.sql": file("ignored, only the root is read"),
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := versions(migrations); !reflect.DeepEqual(got, []string{"20260101000000_first", "20260102000000_second", "20260110000000_up_only"}) {
+		t.Fatalf("order = %v", got)
+	}
+	if migrations[0].Up != "CREATE TABLE first ()" || migrations[0].Down != "DROP TABLE first" {
+		t.Errorf("first = %+v", migrations[0])
+	}
+	if migrations[2].Down != "" {
+		t.Errorf("up only migration has down %q", migrations[2].Down)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		fsys  fstest.MapFS
+		error string
+	}{
+		{"short version", fstest.MapFS{"2026_first.up.sql": file("")}, "invalid migration file name"},
+		{"upper case name", fstest.MapFS{"20260101000000_First.up.sql": file("")}, "invalid migration file name"},
+		{"unknown direction", fstest.MapFS{"20260101000000_first.sideways.sql": file("")}, "invalid migration file name"},
+		{"names differ", fstest.MapFS{
+			"20260101000000_first.up.sql":   file("SELECT 1"),
+			"20260101000000_other.down.sql": file("SELECT 1"),
+		}, "different names"},
+		{"down without up", fstest.MapFS{"20260101000000_first.down.sql": file("SELECT 1")}, "has no up file"},
+		{"empty up", fstest.MapFS{"20260101000000_first.up.sql": file("")}, "has no up file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.fsys)
+			if err == nil || !strings.Contains(err.Error(), tt.error) {
+				t.Errorf("err = %v, want %q", err, tt.error)
+			}
+		})
+	}
+}
+
+var testMigrations = fstest.MapFS{
+	"20260101000000_create_first.up.sql":    file(`CREATE TABLE "first" ("id" int)`),
+	"20260101000000_create_first.down.sql":  file(`DROP TABLE "first"`),
+	"20260102000000_create_second.up.sql":   file(`CREATE TABLE "second" ("id" int)`),
+	"20260103000000_create_third.up.sql":    file(`CREATE TABLE "third" ("id" int)`),
+	"20260103000000_create_third.down.sql":  file(`DROP TABLE "third"`),
+	"20260104000000_create_fourth.up.sql":   file(`CREATE TABLE "fourth" ("id" int)`),
+	"20260104000000_create_fourth.down.sql": file(`DROP TABLE "fourth"`),
+}
+
+func TestUpDownStatus(t *testing.T) {
+	db := databasetest.Open(t)
+	ctx := context.Background()
+	migrator, err := New(db, testMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 4 {
+		t.Fatalf("pending before up = %v", versions(pending))
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := versions(applied); !reflect.DeepEqual(got, []string{
+		"20260101000000_create_first", "20260102000000_create_second", "20260103000000_create_third", "20260104000000_create_fourth",
+	}) {
+		t.Fatalf("applied = %v", got)
+	}
+	for _, table := range []string{"first", "second", "third", "fourth"} {
+		if !db.Migrator().HasTable(table) {
+			t.Errorf("table %s was not created", table)
+		}
+	}
+
+	if applied, err := migrator.Up(ctx); err != nil || len(applied) != 0 {
+		t.Fatalf("second up applied %v, err = %v", versions(applied), err)
+	}
+
+	reverted, err := migrator.Down(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := versions(reverted); !reflect.DeepEqual(got, []string{"20260104000000_create_fourth", "20260103000000_create_third"}) {
+		t.Fatalf("reverted = %v, want newest first", got)
+	}
+	if db.Migrator().HasTable("third") || db.Migrator().HasTable("fourth") {
+		t.Error("reverted tables still exist")
+	}
+
+	// second has no down file, nothing before it may be reverted either
+	reverted, err = migrator.Down(ctx, 2)
+	if !errors.Is(err, ErrNoDownMigration) || len(reverted) != 0 {
+		t.Fatalf("down past second reverted %v, err = %v", versions(reverted), err)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var applyState []bool
+	for _, status := range statuses {
+		applyState = append(applyState, status.AppliedAt != nil)
+	}
+	if !reflect.DeepEqual(applyState, []bool{true, true, false, false}) {
+		t.Errorf("applied = %v", applyState)
+	}
+
+	pending, err = migrator.Pending(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := versions(pending); !reflect.DeepEqual(got, []string{"20260103000000_create_third", "20260104000000_create_fourth"}) {
+		t.Errorf("pending = %v", got)
+	}
+}
+
+func TestUpStopsAtFailure(t *testing.T) {
+	db := databasetest.Open(t)
+	ctx := context.Background()
+	migrator, err := New(db, fstest.MapFS{
+		"20260101000000_create_first.up.sql": file(`CREATE TABLE "first" ("id" int)`),
+		"20260102000000_broken.up.sql":       file(`CREATE TABLE "broken" ("id" int); SELECT * FROM "missing"`),
+		"20260103000000_create_third.up.sql": file(`CREATE TABLE "third" ("id" int)`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err == nil || !strings.Contains(err.Error(), "20260102000000_broken") {
+		t.Fatalf("err = %v", err)
+	}
+	if got := versions(applied); !reflect.DeepEqual(got, []string{"20260101000000_create_first"}) {
+		t.Errorf("applied = %v", got)
+	}
+	// the failed migration rolled back as a whole and stays pending
+	if db.Migrator().HasTable("broken") || db.Migrator().HasTable("third") {
+		t.Error("tables after the failure exist")
+	}
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 {
+		t.Errorf("pending = %v", versions(pending))
+	}
+}
+
+func TestStatusMissingFiles(t *testing.T) {
+	db := databasetest.Open(t)
+	ctx := context.Background()
+	full, err := New(db, testMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := full.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	partial, err := New(db, fstest.MapFS{"20260101000000_create_first.up.sql": testMigrations["20260101000000_create_first.up.sql"]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses, err := partial.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 4 || statuses[0].Missing || !statuses[3].Missing || statuses[3].Name != "create_fourth" {
+		t.Errorf("statuses = %+v", statuses)
+	}
+
+	if _, err := partial.Down(ctx, 1); err == nil || !strings.Contains(err.Error(), "files are missing") {
+		t.Errorf("down without files: err = %v", err)
+	}
+}
+
+func TestUpWaitsForLock(t *testing.T) {
+	db := databasetest.Open(t)
+	ctx := context.Background()
+	migrator, err := New(db, testMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// another instance is migrating
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	holder, err := sqlDB.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer holder.Close()
+	if _, err := holder.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := migrator.Up(ctx)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("up ran while the lock was held, err = %v", err)
+	case <-time.After(300 * time.Millisecond):
+	}
+	if db.Migrator().HasTable("first") {
+		t.Fatal("migrated while the lock was held")
+	}
+
+	if _, err := holder.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", lockKey); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("up did not continue after the lock was released")
+	}
+	if !db.Migrator().HasTable("fourth") {
+		t.Error("migrations were not applied")
+	}
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+const (
+	// EmailVerificationPurposeVerify confirms the user's current email
+	EmailVerificationPurposeVerify = "verify"
+	// EmailVerificationPurposeChange confirms a new email that replaces the current one once verified
+	EmailVerificationPurposeChange = "change"
+)
+
+// EmailVerificationToken proves ownership of Email for the user. Only the hash of
+// the token is stored. A verify token is void once the user's email changes.
+type EmailVerificationToken struct {
+	CustomGormModel
+	UserID    int        `json:"user_id" gorm:"type: int8;index;"`
+	Email     string     `json:"email" gorm:"type: varchar(255);"`
+	Purpose   string     `json:"purpose" gorm:"type: varchar(20);"`
+	TokenHash string     `json:"-" gorm:"type: varchar(64);uniqueIndex;"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"type:timestamptz;"`
+	UsedAt    *time.Time `json:"used_at" gorm:"type:timestamptz;"`
+}
@@ -0,0 +1,40 @@
+package models
+
+// Scan states of a file. A file is quarantined from upload until the scanner has
+// passed it, infected files are kept as a record only, their objects are deleted.
+const (
+	FileScanQuarantine = "quarantine"
+	FileScanClean      = "clean"
+	FileScanInfected   = "infected"
+)
+
+// File is an object in the storage backend. RefCount is the number of model
+// columns pointing at Key, files left unreferenced are removed by the sweeper.
+type File struct {
+	CustomGormModel
+	Key      string `json:"key" gorm:"type: varchar(255);uniqueIndex;"`
+	OwnerID  int    `json:"owner_id" gorm:"type: int8;index;"`
+	Name     string `json:"name" gorm:"type: varchar(255);"`
+	Size     int64  `json:"size" gorm:"type: int8;"`
+	MimeType string `json:"mime_type" gorm:"type: varchar(100);"`
+	SHA256   string `json:"sha256" gorm:"column:sha256;type: varchar(64);index;"`
+	RefCount int    `json:"ref_count" gorm:"type: int8;index;"`
+	// ScanStatus is one of the FileScan states, ScanThreat names what an infected file carries
+	ScanStatus string `json:"scan_status" gorm:"type: varchar(20);index;default:quarantine;"`
+	ScanThreat string `json:"scan_threat" gorm:"type: varchar(255);"`
+
+	// Variants are the resized copies of an image, stored and deleted with it
+	Variants []FileVariant `json:"variants,omitempty" gorm:"foreignKey:FileID;constraint:OnDelete:CASCADE"`
+}
+
+// FileVariant is a resized copy of an uploaded image, see imaging.Pipeline
+type FileVariant struct {
+	CustomGormModel
+	FileID   uint   `json:"file_id" gorm:"index;"`
+	Name     string `json:"name" gorm:"type: varchar(50);"`
+	Key      string `json:"key" gorm:"type: varchar(255);uniqueIndex;"`
+	Width    int    `json:"width" gorm:"type: int8;"`
+	Height   int    `json:"height" gorm:"type: int8;"`
+	Size     int64  `json:"size" gorm:"type: int8;"`
+	MimeType string `json:"mime_type" gorm:"type: varchar(100);"`
+}
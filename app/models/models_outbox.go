@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+const (
+	OutboxStatusPending    = "pending"
+	OutboxStatusProcessing = "processing"
+	OutboxStatusSent       = "sent"
+	OutboxStatusDead       = "dead"
+)
+
+const OutboxKindEmail = "email"
+
+// OutboxMessage is a side effect recorded in the same transaction as the change
+// that caused it and delivered later by the outbox worker
+type OutboxMessage struct {
+	CustomGormModel
+	Kind string `json:"kind" gorm:"type: varchar(50);"`
+	// Payload is never returned by the API, an email body may hold sign in links
+	Payload       string     `json:"-" gorm:"type: text;"`
+	Status        string     `json:"status" gorm:"type: varchar(20);index;"`
+	Attempts      int        `json:"attempts" gorm:"type: int8;"`
+	MaxAttempts   int        `json:"max_attempts" gorm:"type: int8;"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"type:timestamptz;index;"`
+	LockedUntil   *time.Time `json:"locked_until" gorm:"type:timestamptz;"`
+	LastError     string     `json:"last_error" gorm:"type: text;"`
+	SentAt        *time.Time `json:"sent_at" gorm:"type:timestamptz;"`
+}
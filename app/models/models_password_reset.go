@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// PasswordResetToken is a single use token sent by email to reset a forgotten password.
+// Only the hash of the token is stored.
+type PasswordResetToken struct {
+	CustomGormModel
+	UserID    int        `json:"user_id" gorm:"type: int8;index;"`
+	TokenHash string     `json:"-" gorm:"type: varchar(64);uniqueIndex;"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"type:timestamptz;"`
+	UsedAt    *time.Time `json:"used_at" gorm:"type:timestamptz;"`
+	RequestIP string     `json:"request_ip" gorm:"type: varchar(64);"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// RefreshToken is a long lived, single use token. Every refresh rotates it into a
+// new token of the same family, so presenting a used token again means it leaked.
+type RefreshToken struct {
+	CustomGormModel
+	UserID       int        `json:"user_id" gorm:"type: int8;index;"`
+	FamilyID     string     `json:"family_id" gorm:"type: varchar(64);index;"`
+	TokenHash    string     `json:"-" gorm:"type: varchar(64);uniqueIndex;"`
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"type:timestamptz;"`
+	UsedAt       *time.Time `json:"used_at" gorm:"type:timestamptz;"`
+	RevokedAt    *time.Time `json:"revoked_at" gorm:"type:timestamptz;"`
+	ReplacedByID uint       `json:"replaced_by_id" gorm:"type: int8;"`
+	UserAgent    string     `json:"user_agent" gorm:"type: varchar(255);"`
+	IPAddress    string     `json:"ip_address" gorm:"type: varchar(64);"`
+}
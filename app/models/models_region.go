@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Region tables are keyed by the IDs of the imported region data in assets/sql,
+// users reference them through Prov, Kab and Kec.
+
+type Province struct {
+	ProvinceID     int             `json:"province_id" gorm:"primaryKey;autoIncrement:false"`
+	ProvinceName   string          `json:"province_name" gorm:"type: varchar(255);"`
+	ProvinceStatus string          `json:"province_status" gorm:"type: varchar(50);"`
+	CreatedAt      *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time      `json:"updated_at,omitempty"`
+	DeletedAt      *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+type City struct {
+	CityID     int             `json:"city_id" gorm:"primaryKey;autoIncrement:false"`
+	ProvinceID int             `json:"province_id" gorm:"type: int8;index;"`
+	CityName   string          `json:"city_name" gorm:"type: varchar(255);"`
+	CityStatus string          `json:"city_status" gorm:"type: varchar(50);"`
+	PostalCode string          `json:"postal_code" gorm:"type: varchar(10);"`
+	CreatedAt  *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt  *time.Time      `json:"updated_at,omitempty"`
+	DeletedAt  *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+type Subdistrict struct {
+	SubdistrictID     int             `json:"subdistrict_id" gorm:"primaryKey;autoIncrement:false"`
+	CityID            int             `json:"city_id" gorm:"type: int8;index;"`
+	SubdistrictName   string          `json:"subdistrict_name" gorm:"type: varchar(255);"`
+	SubdistrictStatus string          `json:"subdistrict_status" gorm:"type: varchar(50);"`
+	CreatedAt         *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt         *time.Time      `json:"updated_at,omitempty"`
+	DeletedAt         *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
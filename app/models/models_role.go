@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+type Role struct {
+	CustomGormModel
+	Name        string       `json:"name" gorm:"type: varchar(100);uniqueIndex;"`
+	Description string       `json:"description" gorm:"type: text;"`
+	IsDefault   bool         `json:"is_default" gorm:"type: bool;"`
+	Permissions []Permission `json:"permissions" gorm:"many2many:role_permissions;"`
+}
+
+type Permission struct {
+	CustomGormModel
+	Name        string `json:"name" gorm:"type: varchar(100);uniqueIndex;"`
+	Description string `json:"description" gorm:"type: text;"`
+}
+
+// RolePermission is the join table between Role and Permission
+type RolePermission struct {
+	RoleID       uint      `json:"role_id" gorm:"primaryKey"`
+	PermissionID uint      `json:"permission_id" gorm:"primaryKey"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Session is one signed-in device. Its SessionID is carried in the access token
+// as "sid" and doubles as the family ID of the device's refresh tokens.
+type Session struct {
+	CustomGormModel
+	SessionID  string    `json:"session_id" gorm:"type: varchar(64);uniqueIndex;"`
+	UserID     int       `json:"user_id" gorm:"type: int8;index;"`
+	Device     string    `json:"device" gorm:"type: varchar(255);"`
+	UserAgent  string    `json:"user_agent" gorm:"type: varchar(255);"`
+	IPAddress  string    `json:"ip_address" gorm:"type: varchar(64);"`
+	LastSeenAt time.Time `json:"last_seen_at" gorm:"type:timestamptz;"`
+	ExpiresAt  time.Time `json:"expires_at" gorm:"type:timestamptz;"`
+}
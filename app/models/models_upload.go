@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Upload is a resumable upload following the tus protocol. Every PATCH is stored
+// as its own object, listed in Chunks, until the last byte arrives and the chunks
+// are joined into a File.
+type Upload struct {
+	CustomGormModel
+	UploadID     string     `json:"upload_id" gorm:"type: varchar(64);uniqueIndex;"`
+	OwnerID      int        `json:"owner_id" gorm:"type: int8;index;"`
+	UploadLength int64      `json:"upload_length" gorm:"type: int8;"`
+	UploadOffset int64      `json:"upload_offset" gorm:"type: int8;"`
+	Filename     string     `json:"filename" gorm:"type: varchar(255);"`
+	Metadata     string     `json:"metadata" gorm:"type: text;"`
+	Chunks       string     `json:"-" gorm:"type: text;"`
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"type:timestamptz;index;"`
+	FileID       *uint      `json:"file_id" gorm:"type: int8;"`
+	CompletedAt  *time.Time `json:"completed_at" gorm:"type:timestamptz;"`
+
+	// File is the completed file, nil until then or once the file was deleted
+	File *File `json:"file,omitempty" gorm:"foreignKey:FileID;constraint:-"`
+}
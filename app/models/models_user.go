@@ -10,7 +10,7 @@ type User struct {
 	CustomGormModel
 	Name       string    `json:"name" gorm:"type: varchar(255);"`
 	Email      string    `json:"email" gorm:"type: varchar(255);"`
-	Password   string    `json:"password" gorm:"type:varchar(255);"`
+	Password   string    `json:"-" gorm:"type:varchar(255);"`
 	Gender     string    `json:"gender" gorm:"type: varchar(2);"`
 	TglLahir   time.Time `json:"tgl_lahir" gorm:"type:timestamp;"`
 	Phone      string    `json:"phone" gorm:"type: varchar(255);"`
@@ -24,6 +24,13 @@ type User struct {
 	Kec        int       `json:"kec" gorm:"type: int8;"`
 	Kel        string    `json:"kel" gorm:"type: varchar(255);"`
 	PostalCode string    `json:"postal_code" gorm:"type: varchar(255);"`
+
+	// Relations are only loaded when expanded. The region columns predate the
+	// region tables and may hold 0, so no foreign key constraints are created.
+	Role        *Role        `json:"role,omitempty" gorm:"foreignKey:RoleID;constraint:-"`
+	Province    *Province    `json:"province,omitempty" gorm:"foreignKey:Prov;references:ProvinceID;constraint:-"`
+	City        *City        `json:"city,omitempty" gorm:"foreignKey:Kab;references:CityID;constraint:-"`
+	Subdistrict *Subdistrict `json:"subdistrict,omitempty" gorm:"foreignKey:Kec;references:SubdistrictID;constraint:-"`
 }
 
 type CustomGormModel struct {
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"project-name/app/database"
+
+	"gorm.io/gorm"
+)
+
+// UnitOfWork runs several repository calls in one database transaction
+type UnitOfWork interface {
+	// WithTx runs fn in a transaction carried by the context it receives. Repositories
+	// and the session store called with that context use the transaction; it commits
+	// when fn returns nil and rolls back otherwise. Inside a transaction WithTx joins
+	// the one already open.
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type gormUnitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) UnitOfWork {
+	return &gormUnitOfWork{db: db}
+}
+
+func (u *gormUnitOfWork) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withTx(ctx, u.db, func(tx *gorm.DB) error {
+		return fn(database.WithTx(ctx, tx))
+	})
+}
+
+// conn returns the transaction carried by ctx, or db bound to ctx when there is none
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return database.Conn(ctx, db)
+}
+
+// withTx runs fn in the transaction carried by ctx, or in a new one on db
+func withTx(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	return database.Transaction(ctx, db, fn)
+}
@@ -1,43 +1,464 @@
 package repository
 
 import (
+	"context"
+	"errors"
+	"project-name/app/mailer"
 	"project-name/app/middlewares"
 	"project-name/app/models"
 	"project-name/app/reqres"
+	"project-name/app/session"
+	"project-name/app/utils"
 	"project-name/config"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-func Login(emailorphone string) (data models.User, token string, err error) {
-	err = config.DB.Debug().Where("email = ? OR phone = ?", emailorphone, emailorphone).First(&data).Error
+// AuthRepository covers sign-in, sessions, refresh tokens, password reset and
+// email verification. Every method runs in the transaction carried by ctx when
+// there is one, see UnitOfWork.
+type AuthRepository interface {
+	Login(ctx context.Context, emailorphone string) (models.User, error)
+	Register(ctx context.Context, data reqres.UserRequest) (models.User, error)
+	StartSession(ctx context.Context, user models.User, device, userAgent, ip string) (accessToken, refreshToken string, err error)
+	RevokeSession(ctx context.Context, userID int, sessionID string) error
+	RevokeAllSessions(ctx context.Context, userID int, exceptID string) error
+	RotateRefreshToken(ctx context.Context, token, userAgent, ip string) (user models.User, accessToken, refreshToken string, err error)
+	RequestPasswordReset(ctx context.Context, user models.User, ip string) error
+	ResetPasswordWithToken(ctx context.Context, token, newPassword string) (models.User, error)
+	RequestEmailVerification(ctx context.Context, user models.User) (retryAfter time.Duration, err error)
+	RequestEmailChange(ctx context.Context, user models.User, newEmail string) (retryAfter time.Duration, err error)
+	VerifyEmailWithToken(ctx context.Context, token string) (models.User, error)
+}
+
+type authRepository struct {
+	db       *gorm.DB
+	sessions session.Store
+}
+
+func NewAuthRepository(db *gorm.DB, sessions session.Store) AuthRepository {
+	return &authRepository{db: db, sessions: sessions}
+}
+
+func (r *authRepository) Login(ctx context.Context, emailorphone string) (data models.User, err error) {
+	err = conn(ctx, r.db).Debug().Where("email = ? OR phone = ?", emailorphone, emailorphone).First(&data).Error
+
+	return
+}
+
+func (r *authRepository) Register(ctx context.Context, data reqres.UserRequest) (response models.User, err error) {
+	password := middlewares.BcryptPassword(data.Password)
+
+	response = models.User{
+		Name:       data.Name,
+		Email:      data.Email,
+		Password:   password,
+		Gender:     data.Gender,
+		Phone:      data.Phone,
+		Image:      data.Image,
+		Address:    data.Address,
+		IsVerify:   false,
+		Status:     0,
+		Prov:       data.Prov,
+		Kab:        data.Kab,
+		Kec:        data.Kec,
+		Kel:        data.Kel,
+		PostalCode: data.PostalCode,
+	}
+
+	err = withTx(ctx, r.db, func(tx *gorm.DB) error {
+		var role models.Role
+		if err := tx.Where("is_default = ?", true).Order("id").First(&role).Error; err != nil {
+			return err
+		}
+		response.RoleID = int(role.ID)
+
+		if err := tx.Create(&response).Error; err != nil {
+			return err
+		}
+		// a new account owns no files yet, so it cannot take an image here
+		if err := retainFile(tx, response.Image, int(response.ID)); err != nil {
+			return err
+		}
+
+		_, err := requestEmailVerification(tx, response, response.Email, models.EmailVerificationPurposeVerify)
+		return err
+	})
+
+	return
+}
+
+// StartSession signs the user in on a new device: it creates the session, the
+// first refresh token of the session's family and an access token bound to it
+func (r *authRepository) StartSession(ctx context.Context, user models.User, device, userAgent, ip string) (accessToken, refreshToken string, err error) {
+	data, err := session.Create(ctx, r.sessions, int(user.ID), device, userAgent, ip)
 	if err != nil {
 		return
 	}
 
-	token, err = middlewares.AuthMakeToken(data)
+	refreshToken, _, err = createRefreshToken(conn(ctx, r.db), int(user.ID), data.SessionID, userAgent, ip)
 	if err != nil {
 		return
 	}
 
+	accessToken, err = middlewares.AuthMakeToken(user, data.SessionID)
+
 	return
 }
 
-func Register(data reqres.UserRequest) (response models.User, err error) {
-	password := middlewares.BcryptPassword(data.Password)
+// RevokeSession ends one session of the user and the refresh tokens issued to it
+func (r *authRepository) RevokeSession(ctx context.Context, userID int, sessionID string) (err error) {
+	if err = session.Revoke(ctx, r.sessions, userID, sessionID); err != nil {
+		return
+	}
 
-	response = models.User{
-		Name:     data.Name,
-		Email:    data.Email,
-		Password: password,
-		Gender:   data.Gender,
-		Phone:    data.Phone,
-		Image:    data.Image,
-		Address:  data.Address,
-		IsVerify: data.IsVerify,
-		RoleID:   3,
-		Status:   0,
+	err = revokeRefreshTokenFamily(conn(ctx, r.db), sessionID)
+
+	return
+}
+
+// RevokeAllSessions ends every session of the user except exceptID, which may be empty
+func (r *authRepository) RevokeAllSessions(ctx context.Context, userID int, exceptID string) (err error) {
+	if _, err = session.RevokeAll(ctx, r.sessions, userID, exceptID); err != nil {
+		return
 	}
 
-	err = config.DB.Create(&response).Error
+	query := conn(ctx, r.db).Model(&models.RefreshToken{}).Where("user_id = ? AND revoked_at IS NULL", userID)
+	if exceptID != "" {
+		query = query.Where("family_id <> ?", exceptID)
+	}
+	err = query.Update("revoked_at", time.Now()).Error
 
 	return
 }
+
+func createRefreshToken(tx *gorm.DB, userID int, familyID, userAgent, ip string) (token string, data models.RefreshToken, err error) {
+	token, err = utils.GenerateSecureToken(32)
+	if err != nil {
+		return
+	}
+
+	data = models.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(time.Duration(config.LoadConfig().JwtRefreshTokenTTL) * time.Minute),
+		UserAgent: userAgent,
+		IPAddress: ip,
+	}
+
+	err = tx.Create(&data).Error
+
+	return
+}
+
+// RotateRefreshToken consumes a refresh token and returns its owner with a new
+// access token and the next refresh token of the same family. Presenting a token
+// that was already used or revoked revokes the whole family and its session.
+func (r *authRepository) RotateRefreshToken(ctx context.Context, token, userAgent, ip string) (user models.User, accessToken, refreshToken string, err error) {
+	reused := false
+	var sessionID string
+	var sessionUserID int
+
+	err = withTx(ctx, r.db, func(tx *gorm.DB) error {
+		var current models.RefreshToken
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", utils.HashToken(token)).
+			First(&current).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrInvalidRefreshToken
+			}
+			return err
+		}
+
+		sessionID, sessionUserID = current.FamilyID, current.UserID
+
+		if current.UsedAt != nil || current.RevokedAt != nil {
+			reused = true
+			return revokeRefreshTokenFamily(tx, current.FamilyID)
+		}
+
+		if current.ExpiresAt.Before(time.Now()) {
+			return utils.ErrExpiredRefreshToken
+		}
+
+		if err := tx.First(&user, current.UserID).Error; err != nil {
+			return utils.ErrInvalidRefreshToken
+		}
+
+		var next models.RefreshToken
+		refreshToken, next, err = createRefreshToken(tx, current.UserID, current.FamilyID, userAgent, ip)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		return tx.Model(&current).Updates(map[string]interface{}{
+			"used_at":        &now,
+			"replaced_by_id": next.ID,
+		}).Error
+	})
+	if err != nil {
+		return
+	}
+	if reused {
+		session.Revoke(ctx, r.sessions, sessionUserID, sessionID)
+		err = utils.ErrRefreshTokenReused
+		return
+	}
+
+	if err = session.Extend(ctx, r.sessions, sessionID); err != nil {
+		revokeRefreshTokenFamily(conn(ctx, r.db), sessionID)
+		err = utils.ErrInvalidRefreshToken
+		return
+	}
+
+	accessToken, err = middlewares.AuthMakeToken(user, sessionID)
+
+	return
+}
+
+func revokeRefreshTokenFamily(tx *gorm.DB, familyID string) error {
+	return tx.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func BuildSessionResponse(data models.Session, currentSessionID string) reqres.SessionResponse {
+	return reqres.SessionResponse{
+		SessionID:  data.SessionID,
+		Device:     data.Device,
+		UserAgent:  data.UserAgent,
+		IPAddress:  data.IPAddress,
+		CreatedAt:  data.CreatedAt,
+		LastSeenAt: data.LastSeenAt,
+		ExpiresAt:  data.ExpiresAt,
+		Current:    data.SessionID == currentSessionID,
+	}
+}
+
+// RequestPasswordReset invalidates earlier unused reset tokens of the user, issues
+// a new one and queues the email carrying it, all in one transaction
+func (r *authRepository) RequestPasswordReset(ctx context.Context, user models.User, ip string) (err error) {
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return
+	}
+
+	msg, err := forgotPasswordEmail(user, token)
+	if err != nil {
+		return
+	}
+
+	err = withTx(ctx, r.db, func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Model(&models.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", user.ID).
+			Update("used_at", &now).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Create(&models.PasswordResetToken{
+			UserID:    int(user.ID),
+			TokenHash: utils.HashToken(token),
+			ExpiresAt: now.Add(time.Duration(config.LoadConfig().PasswordResetTokenTTL) * time.Minute),
+			RequestIP: ip,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return EnqueueEmail(tx, msg)
+	})
+
+	return
+}
+
+// ResetPasswordWithToken consumes a reset token and sets the owner's new password
+func (r *authRepository) ResetPasswordWithToken(ctx context.Context, token, newPassword string) (user models.User, err error) {
+	err = withTx(ctx, r.db, func(tx *gorm.DB) error {
+		var reset models.PasswordResetToken
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", utils.HashToken(token)).
+			First(&reset).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrInvalidResetToken
+			}
+			return err
+		}
+
+		if reset.UsedAt != nil || reset.ExpiresAt.Before(time.Now()) {
+			return utils.ErrInvalidResetToken
+		}
+
+		if err := tx.First(&user, reset.UserID).Error; err != nil {
+			return utils.ErrInvalidResetToken
+		}
+
+		now := time.Now()
+		if err := tx.Model(&reset).Update("used_at", &now).Error; err != nil {
+			return err
+		}
+
+		user.Password = middlewares.BcryptPassword(newPassword)
+		return tx.Model(&user).Update("password", user.Password).Error
+	})
+
+	return
+}
+
+func forgotPasswordEmail(user models.User, token string) (mailer.Message, error) {
+	cfg := config.LoadConfig()
+
+	return mailer.ForgotPassword(user.Email, mailer.ForgotPasswordData{
+		AppName:      cfg.AppName,
+		ContactEmail: cfg.SmtpSender,
+		FrontendUrl:  cfg.FrontEndUrl,
+		Fullname:     user.Name,
+		ID:           token,
+	})
+}
+
+// RequestEmailVerification issues a verification token for the user's current
+// email and queues the email carrying it. A new token is refused with
+// utils.ErrTooManyRequests until the throttle interval since the previous one
+// has passed, retryAfter tells how long to wait.
+func (r *authRepository) RequestEmailVerification(ctx context.Context, user models.User) (retryAfter time.Duration, err error) {
+	err = withTx(ctx, r.db, func(tx *gorm.DB) error {
+		retryAfter, err = requestEmailVerification(tx, user, user.Email, models.EmailVerificationPurposeVerify)
+		return err
+	})
+
+	return
+}
+
+// RequestEmailChange sends a verification link to newEmail. The user's email is
+// only replaced once that link is followed, until then the current one stays.
+func (r *authRepository) RequestEmailChange(ctx context.Context, user models.User, newEmail string) (retryAfter time.Duration, err error) {
+	err = withTx(ctx, r.db, func(tx *gorm.DB) error {
+		retryAfter, err = requestEmailVerification(tx, user, newEmail, models.EmailVerificationPurposeChange)
+		return err
+	})
+
+	return
+}
+
+func requestEmailVerification(tx *gorm.DB, user models.User, email, purpose string) (retryAfter time.Duration, err error) {
+	throttle := time.Duration(config.LoadConfig().EmailVerificationThrottle) * time.Second
+
+	var last models.EmailVerificationToken
+	err = tx.Where("user_id = ?", user.ID).Order("created_at DESC").First(&last).Error
+	if err == nil && time.Since(last.CreatedAt) < throttle {
+		retryAfter = throttle - time.Since(last.CreatedAt)
+		err = utils.ErrTooManyRequests
+		return
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return
+	}
+
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return
+	}
+
+	msg, err := emailVerificationEmail(user, email, token)
+	if err != nil {
+		return
+	}
+
+	err = tx.Create(&models.EmailVerificationToken{
+		UserID:    int(user.ID),
+		Email:     email,
+		Purpose:   purpose,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(time.Duration(config.LoadConfig().EmailVerificationTokenTTL) * time.Minute),
+	}).Error
+	if err != nil {
+		return
+	}
+
+	err = EnqueueEmail(tx, msg)
+
+	return
+}
+
+// VerifyEmailWithToken consumes a verification token and marks its owner as verified
+func (r *authRepository) VerifyEmailWithToken(ctx context.Context, token string) (user models.User, err error) {
+	err = withTx(ctx, r.db, func(tx *gorm.DB) error {
+		var verify models.EmailVerificationToken
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", utils.HashToken(token)).
+			First(&verify).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrInvalidVerifyToken
+			}
+			return err
+		}
+
+		if verify.UsedAt != nil || verify.ExpiresAt.Before(time.Now()) {
+			return utils.ErrInvalidVerifyToken
+		}
+
+		if err := tx.First(&user, verify.UserID).Error; err != nil {
+			return utils.ErrInvalidVerifyToken
+		}
+
+		updates := map[string]interface{}{
+			"is_verify": true,
+			"status":    1,
+		}
+
+		if verify.Purpose == models.EmailVerificationPurposeChange {
+			var taken int64
+			if err := tx.Model(&models.User{}).Where("email = ? AND id <> ?", verify.Email, user.ID).Count(&taken).Error; err != nil {
+				return err
+			}
+			if taken > 0 {
+				return utils.ErrExistsEmailError
+			}
+			updates["email"] = verify.Email
+			user.Email = verify.Email
+		} else if user.Email != verify.Email {
+			// the address changed after the link was sent
+			return utils.ErrInvalidVerifyToken
+		}
+
+		now := time.Now()
+		if err := tx.Model(&verify).Update("used_at", &now).Error; err != nil {
+			return err
+		}
+
+		// links sent for other addresses are void now
+		err = tx.Model(&models.EmailVerificationToken{}).
+			Where("user_id = ? AND used_at IS NULL", user.ID).
+			Update("used_at", &now).Error
+		if err != nil {
+			return err
+		}
+
+		user.IsVerify = true
+		user.Status = 1
+		return tx.Model(&user).Updates(updates).Error
+	})
+
+	return
+}
+
+func emailVerificationEmail(user models.User, email, token string) (mailer.Message, error) {
+	cfg := config.LoadConfig()
+
+	return mailer.EmailVerification(email, mailer.EmailVerificationData{
+		AppName:      cfg.AppName,
+		ContactEmail: cfg.SmtpSender,
+		FrontendUrl:  cfg.FrontEndUrl,
+		Fullname:     user.Name,
+		ID:           token,
+	})
+}
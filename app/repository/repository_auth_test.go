@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"project-name/app/database/databasetest"
+	"project-name/app/migrate"
+	"project-name/app/models"
+	"project-name/app/session"
+	"project-name/app/utils"
+	"project-name/migrations"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// migratedDB is a test schema with every migration applied
+func migratedDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db := databasetest.Open(t)
+	migrator, err := migrate.New(db, migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestRotateRefreshTokenReuse(t *testing.T) {
+	t.Setenv("JWT_SIGNING_METHOD", "HS256")
+	t.Setenv("JWT_SECRET", "rotate-test")
+	t.Setenv("JWT_ACCESS_TOKEN_TTL", "15")
+	t.Setenv("JWT_REFRESH_TOKEN_TTL", "60")
+
+	db := migratedDB(t)
+	sessions := session.NewDatabaseStore(db)
+	auth := NewAuthRepository(db, sessions)
+	ctx := context.Background()
+
+	user := models.User{Name: "Rotate", Email: "rotate@example.com", RoleID: 3, Status: 1}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	_, first, err := auth.StartSession(ctx, user, "phone", "go test", "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var familyID string
+	if err := db.Model(&models.RefreshToken{}).Where("token_hash = ?", utils.HashToken(first)).Pluck("family_id", &familyID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, second, err := auth.RotateRefreshToken(ctx, first, "go test", "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a stolen copy of the first token is presented after the owner rotated it
+	if _, _, _, err := auth.RotateRefreshToken(ctx, first, "go test", "198.51.100.7"); !errors.Is(err, utils.ErrRefreshTokenReused) {
+		t.Fatalf("reuse: err = %v, want %v", err, utils.ErrRefreshTokenReused)
+	}
+
+	var active int64
+	if err := db.Model(&models.RefreshToken{}).Where("family_id = ? AND revoked_at IS NULL", familyID).Count(&active).Error; err != nil {
+		t.Fatal(err)
+	}
+	if active != 0 {
+		t.Errorf("%d tokens of the family are still active", active)
+	}
+	if _, err := sessions.Get(ctx, familyID); err != session.ErrSessionNotFound {
+		t.Errorf("session: err = %v, want it revoked", err)
+	}
+
+	// the token the owner holds now is dead as well
+	if _, _, _, err := auth.RotateRefreshToken(ctx, second, "go test", "192.0.2.1"); !errors.Is(err, utils.ErrRefreshTokenReused) {
+		t.Errorf("rotated token: err = %v, want %v", err, utils.ErrRefreshTokenReused)
+	}
+}
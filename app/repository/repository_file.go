@@ -0,0 +1,371 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"project-name/app/models"
+	"project-name/app/reqres"
+	"project-name/app/storage"
+	"project-name/app/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrFileInUse is returned when deleting a file that a model still points at
+	ErrFileInUse = errors.New("file is still in use")
+	// ErrFileUnavailable is returned when pointing a model at a key that is not a
+	// clean file of the user doing it
+	ErrFileUnavailable = errors.New("file not found")
+)
+
+// fileReference is a model column holding file keys
+type fileReference struct {
+	Table  string
+	Column string
+}
+
+// fileReferences lists every column that points at files. A column storing keys
+// must be added here and keep RefCount up to date through retainFile and releaseFile.
+var fileReferences = []fileReference{
+	{Table: "users", Column: "image"},
+}
+
+// FileRepository records the uploaded files. Every method runs in the transaction
+// carried by ctx when there is one, see UnitOfWork.
+type FileRepository interface {
+	CreateFile(ctx context.Context, data models.File) (models.File, error)
+	GetFiles(ctx context.Context, ownerID int, param reqres.ReqPaging) (reqres.ResPaging, error)
+	GetFileByID(ctx context.Context, id int) (models.File, error)
+	// GetFileByKey finds the file stored under key, or owning the variant stored there
+	GetFileByKey(ctx context.Context, key string) (models.File, error)
+	// UpdateFile saves what storing the file settled, its size, hash, MIME type and
+	// scan result, and creates its new variants
+	UpdateFile(ctx context.Context, data models.File) (models.File, error)
+	// UpdateScanStatus records the verdict of the scanner on a quarantined file
+	UpdateScanStatus(ctx context.Context, data models.File, status, threat string) (models.File, error)
+	// DeleteFile removes the file from storage and the table, unless it is referenced
+	DeleteFile(ctx context.Context, data models.File) error
+	// SweepOrphans deletes up to limit unreferenced files untouched for olderThan
+	// and returns how many were deleted
+	SweepOrphans(ctx context.Context, olderThan time.Duration, limit int) (int, error)
+}
+
+type fileRepository struct {
+	db *gorm.DB
+}
+
+func NewFileRepository(db *gorm.DB) FileRepository {
+	return &fileRepository{db: db}
+}
+
+func (r *fileRepository) CreateFile(ctx context.Context, data models.File) (response models.File, err error) {
+	response = data
+	err = conn(ctx, r.db).Create(&response).Error
+
+	return
+}
+
+func BuildFileResponse(data models.File) (response reqres.FileResponse) {
+	response = reqres.FileResponse{
+		CustomGormModel: data.CustomGormModel,
+		Key:             data.Key,
+		OwnerID:         data.OwnerID,
+		Name:            data.Name,
+		Size:            data.Size,
+		MimeType:        data.MimeType,
+		SHA256:          data.SHA256,
+		RefCount:        data.RefCount,
+		ScanStatus:      data.ScanStatus,
+		ScanThreat:      data.ScanThreat,
+		URL:             storage.URL(data.Key),
+	}
+
+	if len(data.Variants) > 0 {
+		response.Variants = map[string]reqres.FileVariantResponse{}
+	}
+	for _, variant := range data.Variants {
+		response.Variants[variant.Name] = reqres.FileVariantResponse{
+			Width:    variant.Width,
+			Height:   variant.Height,
+			Size:     variant.Size,
+			MimeType: variant.MimeType,
+			URL:      storage.URL(variant.Key),
+		}
+	}
+
+	return
+}
+
+// FileQuery lists the file columns that can be searched, sorted and filtered through the API
+var FileQuery = utils.QueryBuilder{
+	Searchable: []string{"name"},
+	Sortable: map[string]string{
+		"id":         "id",
+		"name":       "name",
+		"size":       "size",
+		"created_at": "created_at",
+	},
+	Filterable: map[string]utils.FilterField{
+		"mime_type":   {Column: "mime_type", Type: utils.FieldString},
+		"size":        {Column: "size", Type: utils.FieldInt},
+		"ref_count":   {Column: "ref_count", Type: utils.FieldInt},
+		"scan_status": {Column: "scan_status", Type: utils.FieldString},
+		"created_at":  {Column: "created_at", Type: utils.FieldTime},
+	},
+	DefaultSort: "id",
+}
+
+func (r *fileRepository) GetFiles(ctx context.Context, ownerID int, param reqres.ReqPaging) (data reqres.ResPaging, err error) {
+	var out []models.File
+
+	if err = FileQuery.Validate(param); err != nil {
+		return
+	}
+
+	page, err := FileQuery.Find(conn(ctx, r.db).Model(&models.File{}).Where("owner_id = ?", ownerID), param, &out,
+		func(db *gorm.DB) *gorm.DB { return db.Preload("Variants") })
+	if err != nil {
+		return
+	}
+
+	responses := []reqres.FileResponse{}
+	for _, file := range out {
+		responses = append(responses, BuildFileResponse(file))
+	}
+
+	data = utils.PopulatePageResPaging(&param, responses, page)
+
+	return
+}
+
+func (r *fileRepository) GetFileByID(ctx context.Context, id int) (data models.File, err error) {
+	err = conn(ctx, r.db).Preload("Variants").First(&data, id).Error
+
+	return
+}
+
+func (r *fileRepository) GetFileByKey(ctx context.Context, key string) (data models.File, err error) {
+	err = conn(ctx, r.db).Preload("Variants").
+		Where("key = ? OR id IN (SELECT file_id FROM file_variants WHERE key = ?)", key, key).
+		First(&data).Error
+
+	return
+}
+
+func (r *fileRepository) UpdateFile(ctx context.Context, data models.File) (response models.File, err error) {
+	err = withTx(ctx, r.db, func(tx *gorm.DB) error {
+		// ref_count is left alone, it only changes through retainFile and releaseFile
+		err := tx.Model(&models.File{}).Where("id = ?", data.ID).UpdateColumns(map[string]interface{}{
+			"size":        data.Size,
+			"sha256":      data.SHA256,
+			"mime_type":   data.MimeType,
+			"scan_status": data.ScanStatus,
+			"scan_threat": data.ScanThreat,
+			"updated_at":  time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		for _, variant := range data.Variants {
+			if variant.ID != 0 {
+				continue
+			}
+			variant.FileID = data.ID
+			if err := tx.Create(&variant).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Preload("Variants").First(&response, data.ID).Error
+	})
+
+	return
+}
+
+func (r *fileRepository) UpdateScanStatus(ctx context.Context, data models.File, status, threat string) (response models.File, err error) {
+	err = conn(ctx, r.db).Model(&data).UpdateColumns(map[string]interface{}{
+		"scan_status": status,
+		"scan_threat": threat,
+		"updated_at":  time.Now(),
+	}).Error
+	if err != nil {
+		return
+	}
+
+	err = conn(ctx, r.db).Preload("Variants").First(&response, data.ID).Error
+
+	return
+}
+
+func (r *fileRepository) DeleteFile(ctx context.Context, data models.File) error {
+	return withTx(ctx, r.db, func(tx *gorm.DB) error {
+		// the row lock keeps retainFile from referencing the file meanwhile
+		var file models.File
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&file, data.ID).Error; err != nil {
+			return err
+		}
+		if file.RefCount > 0 {
+			return ErrFileInUse
+		}
+
+		return deleteFile(ctx, tx, file)
+	})
+}
+
+func (r *fileRepository) SweepOrphans(ctx context.Context, olderThan time.Duration, limit int) (deleted int, err error) {
+	err = withTx(ctx, r.db, func(tx *gorm.DB) error {
+		var files []models.File
+		err := tx.Raw(`
+			SELECT * FROM files
+			WHERE ref_count <= 0 AND updated_at < ?
+			ORDER BY updated_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED`,
+			time.Now().Add(-olderThan), limit,
+		).Scan(&files).Error
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			// a missed retainFile must not cost a file that is still in use
+			count, err := countFileReferences(tx, file.Key)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				if err := tx.Model(&file).Update("ref_count", count).Error; err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := deleteFile(ctx, tx, file); err != nil {
+				return err
+			}
+			deleted++
+		}
+
+		return nil
+	})
+
+	return
+}
+
+// deleteFile removes the objects before the row, so a failure leaves the row for
+// the next attempt rather than untracked objects. The variant rows go with the
+// file row through their foreign key.
+func deleteFile(ctx context.Context, tx *gorm.DB, file models.File) error {
+	var variants []models.FileVariant
+	if err := tx.Where("file_id = ?", file.ID).Find(&variants).Error; err != nil {
+		return err
+	}
+
+	for _, variant := range variants {
+		if err := storage.Default.Delete(ctx, variant.Key); err != nil {
+			return err
+		}
+	}
+	if err := storage.Default.Delete(ctx, file.Key); err != nil {
+		return err
+	}
+
+	return tx.Unscoped().Delete(&file).Error
+}
+
+// variantURLs returns the signed variant URLs of the files with the given keys,
+// by file key and variant name
+func variantURLs(db *gorm.DB, keys []string) (urls map[string]map[string]string, err error) {
+	urls = map[string]map[string]string{}
+	if len(keys) == 0 {
+		return
+	}
+
+	var rows []struct {
+		FileKey string
+		Name    string
+		Key     string
+	}
+	err = db.Table("file_variants").
+		Select("files.key AS file_key, file_variants.name, file_variants.key").
+		Joins("JOIN files ON files.id = file_variants.file_id").
+		Where("files.key IN ?", keys).
+		Scan(&rows).Error
+	if err != nil {
+		return
+	}
+
+	for _, row := range rows {
+		if urls[row.FileKey] == nil {
+			urls[row.FileKey] = map[string]string{}
+		}
+		urls[row.FileKey][row.Name] = storage.URL(row.Key)
+	}
+
+	return
+}
+
+func countFileReferences(tx *gorm.DB, key string) (total int64, err error) {
+	for _, ref := range fileReferences {
+		var count int64
+		if err = tx.Table(ref.Table).Where(clause.Eq{Column: clause.Column{Name: ref.Column}, Value: key}).Count(&count).Error; err != nil {
+			return
+		}
+		total += count
+	}
+
+	return
+}
+
+// retainFile records one more reference to key, which must be a clean file
+// uploaded by ownerID, so nobody can expose or pin the files of someone else
+func retainFile(tx *gorm.DB, key string, ownerID int) error {
+	if key == "" {
+		return nil
+	}
+
+	result := tx.Model(&models.File{}).
+		Where("key = ? AND owner_id = ? AND scan_status = ?", key, ownerID, models.FileScanClean).
+		UpdateColumns(map[string]interface{}{
+			"ref_count":  gorm.Expr("ref_count + 1"),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrFileUnavailable
+	}
+
+	return nil
+}
+
+// releaseFile drops one reference to key. updated_at starts the grace period
+// after which the sweeper deletes a file that is no longer referenced.
+func releaseFile(tx *gorm.DB, key string) error {
+	if key == "" {
+		return nil
+	}
+
+	return tx.Model(&models.File{}).Where("key = ?", key).
+		UpdateColumns(map[string]interface{}{
+			"ref_count":  gorm.Expr("GREATEST(ref_count - 1, 0)"),
+			"updated_at": time.Now(),
+		}).Error
+}
+
+// replaceFile moves a reference from oldKey to newKey, see retainFile
+func replaceFile(tx *gorm.DB, oldKey, newKey string, ownerID int) error {
+	if oldKey == newKey {
+		return nil
+	}
+	if err := releaseFile(tx, oldKey); err != nil {
+		return err
+	}
+
+	return retainFile(tx, newKey, ownerID)
+}
@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"project-name/app/mailer"
+	"project-name/app/models"
+	"project-name/app/reqres"
+	"project-name/app/utils"
+	"project-name/config"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// outboxLease is how long a claimed message stays reserved by one worker. A
+// message still processing after its lease, because the worker died, is claimed again.
+const outboxLease = 5 * time.Minute
+
+// maxOutboxBackoff caps the delay between two attempts
+const maxOutboxBackoff = 6 * time.Hour
+
+// ErrOutboxLeaseLost is returned when a message was claimed again by another
+// worker before this one could record its delivery
+var ErrOutboxLeaseLost = errors.New("outbox message lease was lost")
+
+// OutboxRepository claims, delivers and re-drives outbox messages. Messages are
+// queued with EnqueueEmail on the transaction of the change they belong to.
+type OutboxRepository interface {
+	// ClaimOutboxMessages reserves up to limit due messages for this worker.
+	// SKIP LOCKED lets several instances poll the same table without double sending.
+	ClaimOutboxMessages(ctx context.Context, limit int) ([]models.OutboxMessage, error)
+	// DeliverOutboxMessage sends one claimed message and records the outcome. Failures
+	// are retried with exponential backoff until MaxAttempts, then dead-lettered.
+	DeliverOutboxMessage(ctx context.Context, data models.OutboxMessage) error
+	GetOutboxMessages(ctx context.Context, status string, param reqres.ReqPaging) (reqres.ResPaging, error)
+	GetOutboxMessageByID(ctx context.Context, id int) (models.OutboxMessage, error)
+	// RetryOutboxMessage puts a dead or pending message back in the queue with a fresh attempt budget
+	RetryOutboxMessage(ctx context.Context, data models.OutboxMessage) (models.OutboxMessage, error)
+	// RetryDeadOutboxMessages re-drives every dead-lettered message and returns how many were queued
+	RetryDeadOutboxMessages(ctx context.Context) (int64, error)
+}
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// EnqueueEmail records the email in the outbox on tx, so it is only sent when the
+// surrounding transaction commits
+func EnqueueEmail(tx *gorm.DB, msg mailer.Message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&models.OutboxMessage{
+		Kind:          models.OutboxKindEmail,
+		Payload:       string(payload),
+		Status:        models.OutboxStatusPending,
+		MaxAttempts:   config.LoadConfig().OutboxMaxAttempts,
+		NextAttemptAt: time.Now(),
+	}).Error
+}
+
+func (r *outboxRepository) ClaimOutboxMessages(ctx context.Context, limit int) (data []models.OutboxMessage, err error) {
+	now := time.Now()
+
+	err = conn(ctx, r.db).Raw(`
+		UPDATE outbox_messages SET status = ?, locked_until = ?, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM outbox_messages
+			WHERE deleted_at IS NULL
+				AND ((status = ? AND next_attempt_at <= ?) OR (status = ? AND locked_until < ?))
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		models.OutboxStatusProcessing, now.Add(outboxLease), now,
+		models.OutboxStatusPending, now, models.OutboxStatusProcessing, now,
+		limit,
+	).Scan(&data).Error
+
+	return
+}
+
+func (r *outboxRepository) DeliverOutboxMessage(ctx context.Context, data models.OutboxMessage) (err error) {
+	err = deliverOutboxPayload(data)
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"attempts":     data.Attempts + 1,
+		"locked_until": nil,
+	}
+
+	if err == nil {
+		updates["status"] = models.OutboxStatusSent
+		updates["sent_at"] = &now
+		updates["last_error"] = ""
+		updates["payload"] = sentOutboxPayload(data)
+	} else if data.Attempts+1 >= data.MaxAttempts {
+		updates["status"] = models.OutboxStatusDead
+		updates["last_error"] = err.Error()
+	} else {
+		updates["status"] = models.OutboxStatusPending
+		updates["next_attempt_at"] = now.Add(outboxBackoff(data.Attempts + 1))
+		updates["last_error"] = err.Error()
+	}
+
+	// the lease may have run out during delivery and the message been claimed by
+	// another worker, only the holder of the current lease records the outcome
+	result := conn(ctx, r.db).Model(&models.OutboxMessage{}).
+		Where("id = ? AND status = ? AND locked_until = ?", data.ID, models.OutboxStatusProcessing, data.LockedUntil).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOutboxLeaseLost
+	}
+
+	return
+}
+
+func deliverOutboxPayload(data models.OutboxMessage) error {
+	switch data.Kind {
+	case models.OutboxKindEmail:
+		var msg mailer.Message
+		if err := json.Unmarshal([]byte(data.Payload), &msg); err != nil {
+			return err
+		}
+		return mailer.Default().Send(msg)
+	default:
+		return fmt.Errorf("unknown outbox message kind %q", data.Kind)
+	}
+}
+
+// sentOutboxPayload trims a delivered email to its recipients and subject, the
+// links in its body must not outlive the delivery
+func sentOutboxPayload(data models.OutboxMessage) string {
+	var msg mailer.Message
+	if data.Kind != models.OutboxKindEmail || json.Unmarshal([]byte(data.Payload), &msg) != nil {
+		return ""
+	}
+
+	payload, _ := json.Marshal(mailer.Message{To: msg.To, Subject: msg.Subject})
+
+	return string(payload)
+}
+
+// BuildOutboxMessageResponse adds the recipients and subject of an email, see
+// reqres.OutboxMessageResponse
+func BuildOutboxMessageResponse(data models.OutboxMessage) (response reqres.OutboxMessageResponse) {
+	response.OutboxMessage = data
+
+	var msg mailer.Message
+	if data.Kind == models.OutboxKindEmail && json.Unmarshal([]byte(data.Payload), &msg) == nil {
+		response.To, response.Subject = msg.To, msg.Subject
+	}
+
+	return
+}
+
+// outboxBackoff doubles the base delay with every attempt and adds up to 20% jitter
+func outboxBackoff(attempt int) time.Duration {
+	delay := time.Duration(config.LoadConfig().OutboxBaseBackoff) * time.Second
+	for i := 1; i < attempt && delay < maxOutboxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxOutboxBackoff {
+		delay = maxOutboxBackoff
+	}
+
+	return delay + time.Duration(rand.Int63n(int64(delay)/5+1))
+}
+
+// OutboxQuery lists the outbox columns that can be searched, sorted and filtered through the API
+var OutboxQuery = utils.QueryBuilder{
+	Searchable: []string{"last_error"},
+	Sortable: map[string]string{
+		"id":              "id",
+		"attempts":        "attempts",
+		"next_attempt_at": "next_attempt_at",
+		"created_at":      "created_at",
+	},
+	Filterable: map[string]utils.FilterField{
+		"kind":            {Column: "kind", Type: utils.FieldString},
+		"status":          {Column: "status", Type: utils.FieldString},
+		"attempts":        {Column: "attempts", Type: utils.FieldInt},
+		"next_attempt_at": {Column: "next_attempt_at", Type: utils.FieldTime},
+		"sent_at":         {Column: "sent_at", Type: utils.FieldTime},
+		"created_at":      {Column: "created_at", Type: utils.FieldTime},
+	},
+	DefaultSort: "id",
+}
+
+func (r *outboxRepository) GetOutboxMessages(ctx context.Context, status string, param reqres.ReqPaging) (data reqres.ResPaging, err error) {
+	var out []models.OutboxMessage
+
+	if status != "" {
+		param.Filters = append(param.Filters, reqres.Filter{Field: "status", Value: status})
+	}
+
+	if err = OutboxQuery.Validate(param); err != nil {
+		return
+	}
+
+	page, err := OutboxQuery.Find(conn(ctx, r.db).Model(&models.OutboxMessage{}), param, &out)
+	if err != nil {
+		return
+	}
+
+	responses := []reqres.OutboxMessageResponse{}
+	for _, message := range out {
+		responses = append(responses, BuildOutboxMessageResponse(message))
+	}
+
+	data = utils.PopulatePageResPaging(&param, responses, page)
+
+	return
+}
+
+func (r *outboxRepository) GetOutboxMessageByID(ctx context.Context, id int) (data models.OutboxMessage, err error) {
+	err = conn(ctx, r.db).First(&data, id).Error
+
+	return
+}
+
+func (r *outboxRepository) RetryOutboxMessage(ctx context.Context, data models.OutboxMessage) (response models.OutboxMessage, err error) {
+	if data.Status == models.OutboxStatusSent || data.Status == models.OutboxStatusProcessing {
+		err = errors.New("only pending or dead messages can be retried")
+		return
+	}
+
+	err = conn(ctx, r.db).Model(&data).Updates(map[string]interface{}{
+		"status":          models.OutboxStatusPending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+		"locked_until":    nil,
+	}).Error
+	if err != nil {
+		return
+	}
+
+	err = conn(ctx, r.db).First(&response, data.ID).Error
+
+	return
+}
+
+func (r *outboxRepository) RetryDeadOutboxMessages(ctx context.Context) (count int64, err error) {
+	result := conn(ctx, r.db).Model(&models.OutboxMessage{}).
+		Where("status = ?", models.OutboxStatusDead).
+		Updates(map[string]interface{}{
+			"status":          models.OutboxStatusPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+		})
+
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"context"
+	"project-name/app/models"
+	"project-name/app/reqres"
+	"project-name/app/utils"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// regionIndexTTL is how long the in-memory region index serves search and postal
+// code lookups before it is reloaded. Region data only changes through seeding.
+const regionIndexTTL = time.Hour
+
+// RegionRepository reads provinces, cities and subdistricts
+type RegionRepository interface {
+	GetProvinces(ctx context.Context, search string) ([]models.Province, error)
+	GetProvinceByID(ctx context.Context, id int) (models.Province, error)
+	GetCitiesByProvince(ctx context.Context, provinceID int, search string) ([]models.City, error)
+	GetCityByID(ctx context.Context, id int) (models.City, error)
+	GetSubdistrictsByCity(ctx context.Context, cityID int, search string) ([]models.Subdistrict, error)
+	GetSubdistrictByID(ctx context.Context, id int) (models.Subdistrict, error)
+	// SearchRegions matches query against region names, tolerating prefixes and
+	// typos. level limits the search to one level when it is not empty.
+	SearchRegions(ctx context.Context, query, level string, limit int) ([]reqres.RegionResult, error)
+	GetCitiesByPostalCode(ctx context.Context, postalCode string) ([]reqres.RegionResult, error)
+}
+
+type regionRepository struct {
+	db *gorm.DB
+
+	mu       sync.Mutex
+	index    *regionIndex
+	loadedAt time.Time
+}
+
+func NewRegionRepository(db *gorm.DB) RegionRepository {
+	return &regionRepository{db: db}
+}
+
+func (r *regionRepository) GetProvinces(ctx context.Context, search string) (data []models.Province, err error) {
+	err = conn(ctx, r.db).Order("province_name").Find(&data).Error
+	if err != nil || search == "" {
+		return
+	}
+
+	data = filterByName(data, search, func(p models.Province) string { return p.ProvinceName })
+
+	return
+}
+
+func (r *regionRepository) GetProvinceByID(ctx context.Context, id int) (data models.Province, err error) {
+	err = conn(ctx, r.db).First(&data, "province_id = ?", id).Error
+
+	return
+}
+
+func (r *regionRepository) GetCitiesByProvince(ctx context.Context, provinceID int, search string) (data []models.City, err error) {
+	err = conn(ctx, r.db).Where("province_id = ?", provinceID).Order("city_name").Find(&data).Error
+	if err != nil || search == "" {
+		return
+	}
+
+	data = filterByName(data, search, func(c models.City) string { return c.CityName })
+
+	return
+}
+
+func (r *regionRepository) GetCityByID(ctx context.Context, id int) (data models.City, err error) {
+	err = conn(ctx, r.db).First(&data, "city_id = ?", id).Error
+
+	return
+}
+
+func (r *regionRepository) GetSubdistrictsByCity(ctx context.Context, cityID int, search string) (data []models.Subdistrict, err error) {
+	err = conn(ctx, r.db).Where("city_id = ?", cityID).Order("subdistrict_name").Find(&data).Error
+	if err != nil || search == "" {
+		return
+	}
+
+	data = filterByName(data, search, func(s models.Subdistrict) string { return s.SubdistrictName })
+
+	return
+}
+
+func (r *regionRepository) GetSubdistrictByID(ctx context.Context, id int) (data models.Subdistrict, err error) {
+	err = conn(ctx, r.db).First(&data, "subdistrict_id = ?", id).Error
+
+	return
+}
+
+func (r *regionRepository) SearchRegions(ctx context.Context, query, level string, limit int) (results []reqres.RegionResult, err error) {
+	index, err := r.loadIndex(ctx)
+	if err != nil {
+		return
+	}
+
+	type match struct {
+		result reqres.RegionResult
+		score  int
+		rank   int
+	}
+	var matches []match
+
+	levels := []struct {
+		name       string
+		candidates []reqres.RegionResult
+	}{
+		{reqres.RegionProvince, index.provinces},
+		{reqres.RegionCity, index.cities},
+		{reqres.RegionSubdistrict, index.subdistricts},
+	}
+	for rank, l := range levels {
+		if level != "" && l.name != level {
+			continue
+		}
+		for _, candidate := range l.candidates {
+			if score, ok := utils.MatchName(query, candidate.Name); ok {
+				matches = append(matches, match{result: candidate, score: score, rank: rank})
+			}
+		}
+	}
+
+	// best score first, then wider regions and shorter names
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.score != b.score {
+			return a.score < b.score
+		}
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		return len(a.result.Name) < len(b.result.Name)
+	})
+
+	results = []reqres.RegionResult{}
+	for i := 0; i < len(matches) && i < limit; i++ {
+		results = append(results, matches[i].result)
+	}
+
+	return
+}
+
+func (r *regionRepository) GetCitiesByPostalCode(ctx context.Context, postalCode string) (results []reqres.RegionResult, err error) {
+	index, err := r.loadIndex(ctx)
+	if err != nil {
+		return
+	}
+
+	postalCode = strings.TrimSpace(postalCode)
+	results = []reqres.RegionResult{}
+	for _, city := range index.cities {
+		if city.PostalCode == postalCode {
+			results = append(results, city)
+		}
+	}
+
+	return
+}
+
+// regionIndex holds every region with its parent names resolved, ordered by name
+// within each level
+type regionIndex struct {
+	provinces    []reqres.RegionResult
+	cities       []reqres.RegionResult
+	subdistricts []reqres.RegionResult
+}
+
+func (r *regionRepository) loadIndex(ctx context.Context) (*regionIndex, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.index != nil && time.Since(r.loadedAt) < regionIndexTTL {
+		return r.index, nil
+	}
+
+	db := conn(ctx, r.db)
+
+	var provinces []models.Province
+	if err := db.Order("province_name").Find(&provinces).Error; err != nil {
+		return nil, err
+	}
+	var cities []models.City
+	if err := db.Order("city_name").Find(&cities).Error; err != nil {
+		return nil, err
+	}
+	var subdistricts []models.Subdistrict
+	if err := db.Order("subdistrict_name").Find(&subdistricts).Error; err != nil {
+		return nil, err
+	}
+
+	index := &regionIndex{}
+	provinceNames := map[int]string{}
+	for _, p := range provinces {
+		provinceNames[p.ProvinceID] = p.ProvinceName
+		index.provinces = append(index.provinces, reqres.RegionResult{
+			Level:        reqres.RegionProvince,
+			ID:           p.ProvinceID,
+			Name:         p.ProvinceName,
+			ProvinceID:   p.ProvinceID,
+			ProvinceName: p.ProvinceName,
+		})
+	}
+
+	cityByID := map[int]reqres.RegionResult{}
+	for _, c := range cities {
+		city := reqres.RegionResult{
+			Level:        reqres.RegionCity,
+			ID:           c.CityID,
+			Name:         c.CityName,
+			ProvinceID:   c.ProvinceID,
+			ProvinceName: provinceNames[c.ProvinceID],
+			CityID:       c.CityID,
+			CityName:     c.CityName,
+			PostalCode:   c.PostalCode,
+		}
+		cityByID[c.CityID] = city
+		index.cities = append(index.cities, city)
+	}
+
+	for _, s := range subdistricts {
+		city := cityByID[s.CityID]
+		index.subdistricts = append(index.subdistricts, reqres.RegionResult{
+			Level:        reqres.RegionSubdistrict,
+			ID:           s.SubdistrictID,
+			Name:         s.SubdistrictName,
+			ProvinceID:   city.ProvinceID,
+			ProvinceName: city.ProvinceName,
+			CityID:       s.CityID,
+			CityName:     city.Name,
+			PostalCode:   city.PostalCode,
+		})
+	}
+
+	r.index, r.loadedAt = index, time.Now()
+
+	return index, nil
+}
+
+// filterByName keeps the items whose name matches search, best matches first
+func filterByName[T any](items []T, search string, name func(T) string) []T {
+	type scored struct {
+		item  T
+		score int
+	}
+	var matches []scored
+	for _, item := range items {
+		if score, ok := utils.MatchName(search, name(item)); ok {
+			matches = append(matches, scored{item: item, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score < matches[j].score })
+
+	out := make([]T, 0, len(matches))
+	for _, match := range matches {
+		out = append(out, match.item)
+	}
+
+	return out
+}
@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"project-name/app/models"
+	"project-name/app/reqres"
+	"project-name/app/utils"
+
+	"gorm.io/gorm"
+)
+
+// Permissions known to the application. Every permission is granted to the
+// super admin role on seed, new ones only need to be added here.
+var DefaultPermissions = []models.Permission{
+	{Name: "user.login", Description: "Login to the user app"},
+	{Name: "admin.login", Description: "Login to the admin panel"},
+	{Name: "user.read", Description: "List and view users"},
+	{Name: "user.create", Description: "Create users"},
+	{Name: "user.update", Description: "Update users"},
+	{Name: "user.delete", Description: "Delete users"},
+	{Name: "user.assign_role", Description: "Set the role of users, limited to roles granting no more than the own role"},
+	{Name: "role.read", Description: "List and view roles and permissions"},
+	{Name: "role.create", Description: "Create roles"},
+	{Name: "role.update", Description: "Update roles and their permissions"},
+	{Name: "role.delete", Description: "Delete roles"},
+	{Name: "file.upload", Description: "Upload files"},
+	{Name: "file.read", Description: "Download and share files of other users"},
+	{Name: "outbox.manage", Description: "Inspect and retry queued emails"},
+}
+
+// Roles created on seed. The IDs are fixed because users registered before
+// roles existed were stored with role_id 3.
+var DefaultRoles = []struct {
+	ID          uint
+	Name        string
+	Description string
+	IsDefault   bool
+	Permissions []string
+}{
+	{ID: 1, Name: "Super Admin", Description: "Full access", Permissions: nil},
+	{ID: 2, Name: "Admin", Description: "Manage users", Permissions: []string{
+		"admin.login", "user.login", "user.read", "user.create", "user.update", "user.delete", "user.assign_role", "role.read", "file.upload", "file.read",
+	}},
+	{ID: 3, Name: "User", Description: "Registered user", IsDefault: true, Permissions: []string{
+		"user.login", "file.upload",
+	}},
+}
+
+// SeedRolesAndPermissions creates the default permissions and roles if they do not exist yet.
+// Existing roles keep their permissions, only the super admin is topped up with new ones.
+func SeedRolesAndPermissions(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, permission := range DefaultPermissions {
+			p := permission
+			if err := tx.Where(models.Permission{Name: p.Name}).Attrs(models.Permission{Description: p.Description}).FirstOrCreate(&p).Error; err != nil {
+				return err
+			}
+		}
+
+		var allPermissions []models.Permission
+		if err := tx.Find(&allPermissions).Error; err != nil {
+			return err
+		}
+
+		for _, def := range DefaultRoles {
+			var role models.Role
+			err := tx.First(&role, def.ID).Error
+			if err == nil {
+				if def.Permissions == nil {
+					if err := tx.Model(&role).Association("Permissions").Append(allPermissions); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			if err != gorm.ErrRecordNotFound {
+				return err
+			}
+
+			role = models.Role{Name: def.Name, Description: def.Description, IsDefault: def.IsDefault}
+			role.ID = def.ID
+
+			if def.Permissions == nil {
+				role.Permissions = allPermissions
+			} else if err := tx.Where("name IN ?", def.Permissions).Find(&role.Permissions).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Omit("Permissions.*").Create(&role).Error; err != nil {
+				return err
+			}
+		}
+
+		// roles were inserted with explicit IDs, move the sequence past them
+		return tx.Exec("SELECT setval(pg_get_serial_sequence('roles', 'id'), (SELECT MAX(id) FROM roles))").Error
+	})
+}
+
+// RoleRepository reads and writes roles and answers permission checks. Every
+// method runs in the transaction carried by ctx when there is one, see UnitOfWork.
+type RoleRepository interface {
+	// UserHasPermission checks the user's current role, not the role in the token,
+	// so a role change takes effect without signing in again
+	UserHasPermission(ctx context.Context, userID int, permission string) bool
+	RoleHasPermission(ctx context.Context, roleID int, permission string) bool
+	// RoleExceedsUser reports whether the role grants a permission the user's own role lacks
+	RoleExceedsUser(ctx context.Context, roleID, userID int) (bool, error)
+	GetDefaultRole(ctx context.Context) (models.Role, error)
+	CreateRole(ctx context.Context, data reqres.RoleRequest) (models.Role, error)
+	GetRoles(ctx context.Context, param reqres.ReqPaging) (reqres.ResPaging, error)
+	GetAllRoles(ctx context.Context) ([]models.Role, error)
+	GetRoleByID(ctx context.Context, id int) (models.Role, error)
+	UpdateRole(ctx context.Context, data models.Role, req reqres.RoleRequest) (models.Role, error)
+	CountUsersWithRole(ctx context.Context, roleID int) int64
+	DeleteRole(ctx context.Context, data models.Role) error
+	GetAllPermissions(ctx context.Context) ([]models.Permission, error)
+}
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+func (r *roleRepository) UserHasPermission(ctx context.Context, userID int, permission string) bool {
+	var count int64
+	conn(ctx, r.db).Table("users").
+		Joins("JOIN role_permissions ON role_permissions.role_id = users.role_id").
+		Joins("JOIN permissions ON permissions.id = role_permissions.permission_id").
+		Where("users.id = ? AND users.deleted_at IS NULL AND permissions.name = ? AND permissions.deleted_at IS NULL", userID, permission).
+		Count(&count)
+
+	return count > 0
+}
+
+func (r *roleRepository) RoleHasPermission(ctx context.Context, roleID int, permission string) bool {
+	var count int64
+	conn(ctx, r.db).Table("role_permissions").
+		Joins("JOIN permissions ON permissions.id = role_permissions.permission_id").
+		Where("role_permissions.role_id = ? AND permissions.name = ? AND permissions.deleted_at IS NULL", roleID, permission).
+		Count(&count)
+
+	return count > 0
+}
+
+func (r *roleRepository) RoleExceedsUser(ctx context.Context, roleID, userID int) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).Table("role_permissions").
+		Joins("JOIN permissions ON permissions.id = role_permissions.permission_id AND permissions.deleted_at IS NULL").
+		Where("role_permissions.role_id = ?", roleID).
+		Where(`role_permissions.permission_id NOT IN (
+			SELECT own.permission_id FROM users JOIN role_permissions own ON own.role_id = users.role_id
+			WHERE users.id = ? AND users.deleted_at IS NULL)`, userID).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+func (r *roleRepository) GetDefaultRole(ctx context.Context) (data models.Role, err error) {
+	err = conn(ctx, r.db).Where("is_default = ?", true).Order("id").First(&data).Error
+
+	return
+}
+
+func (r *roleRepository) CreateRole(ctx context.Context, data reqres.RoleRequest) (response models.Role, err error) {
+	err = withTx(ctx, r.db, func(tx *gorm.DB) error {
+		if data.IsDefault {
+			if err := tx.Model(&models.Role{}).Where("is_default = ?", true).Update("is_default", false).Error; err != nil {
+				return err
+			}
+		}
+
+		response = models.Role{
+			Name:        data.Name,
+			Description: data.Description,
+			IsDefault:   data.IsDefault,
+		}
+		if err := tx.Where("name IN ?", data.Permissions).Find(&response.Permissions).Error; err != nil {
+			return err
+		}
+
+		return tx.Omit("Permissions.*").Create(&response).Error
+	})
+
+	return
+}
+
+// RoleQuery lists the role columns that can be searched, sorted and filtered through the API
+var RoleQuery = utils.QueryBuilder{
+	Searchable: []string{"name"},
+	Sortable: map[string]string{
+		"id":         "id",
+		"name":       "name",
+		"created_at": "created_at",
+	},
+	Filterable: map[string]utils.FilterField{
+		"id":         {Column: "id", Type: utils.FieldInt},
+		"name":       {Column: "name", Type: utils.FieldString},
+		"is_default": {Column: "is_default", Type: utils.FieldBool},
+		"created_at": {Column: "created_at", Type: utils.FieldTime},
+	},
+	DefaultSort: "id",
+}
+
+func (r *roleRepository) GetRoles(ctx context.Context, param reqres.ReqPaging) (data reqres.ResPaging, err error) {
+	var out []models.Role
+
+	if err = RoleQuery.Validate(param); err != nil {
+		return
+	}
+
+	page, err := RoleQuery.Find(conn(ctx, r.db).Model(&models.Role{}), param, &out, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Permissions")
+	})
+	if err != nil {
+		return
+	}
+
+	data = utils.PopulatePageResPaging(&param, out, page)
+
+	return
+}
+
+func (r *roleRepository) GetAllRoles(ctx context.Context) (data []models.Role, err error) {
+	err = conn(ctx, r.db).Preload("Permissions").Order("id").Find(&data).Error
+
+	return
+}
+
+func (r *roleRepository) GetRoleByID(ctx context.Context, id int) (data models.Role, err error) {
+	err = conn(ctx, r.db).Preload("Permissions").First(&data, id).Error
+
+	return
+}
+
+func (r *roleRepository) UpdateRole(ctx context.Context, data models.Role, req reqres.RoleRequest) (response models.Role, err error) {
+	err = withTx(ctx, r.db, func(tx *gorm.DB) error {
+		if req.IsDefault && !data.IsDefault {
+			if err := tx.Model(&models.Role{}).Where("is_default = ?", true).Update("is_default", false).Error; err != nil {
+				return err
+			}
+		}
+
+		data.Name = req.Name
+		data.Description = req.Description
+		data.IsDefault = req.IsDefault
+		if err := tx.Omit("Permissions").Save(&data).Error; err != nil {
+			return err
+		}
+
+		if req.Permissions != nil {
+			var permissions []models.Permission
+			if err := tx.Where("name IN ?", req.Permissions).Find(&permissions).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&data).Association("Permissions").Replace(permissions); err != nil {
+				return err
+			}
+		}
+
+		return tx.Preload("Permissions").First(&response, data.ID).Error
+	})
+
+	return
+}
+
+func (r *roleRepository) CountUsersWithRole(ctx context.Context, roleID int) (count int64) {
+	conn(ctx, r.db).Model(&models.User{}).Where("role_id = ?", roleID).Count(&count)
+
+	return
+}
+
+func (r *roleRepository) DeleteRole(ctx context.Context, data models.Role) (err error) {
+	err = withTx(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Model(&data).Association("Permissions").Clear(); err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&data).Error
+	})
+
+	return
+}
+
+func (r *roleRepository) GetAllPermissions(ctx context.Context) (data []models.Permission, err error) {
+	err = conn(ctx, r.db).Order("name").Find(&data).Error
+
+	return
+}
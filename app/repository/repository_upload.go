@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"project-name/app/models"
+	"project-name/app/storage"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrUploadOffsetMismatch is returned when another request moved the upload
+// offset since it was read
+var ErrUploadOffsetMismatch = errors.New("upload offset mismatch")
+
+// ErrUploadClaimed is returned when another request is already storing, or has
+// stored, the file of the upload
+var ErrUploadClaimed = errors.New("upload is already being completed")
+
+// UploadRepository keeps the state of resumable uploads. Every method runs in the
+// transaction carried by ctx when there is one, see UnitOfWork.
+type UploadRepository interface {
+	CreateUpload(ctx context.Context, data models.Upload) (models.Upload, error)
+	GetUpload(ctx context.Context, uploadID string) (models.Upload, error)
+	// AppendChunk records the object key holding the next size bytes and moves the
+	// offset, provided it is still where data has it
+	AppendChunk(ctx context.Context, data models.Upload, key string, size int64, expiresAt time.Time) (models.Upload, error)
+	// ClaimUpload marks the upload completed, provided no other request did, so
+	// only one request stores its file. See ErrUploadClaimed.
+	ClaimUpload(ctx context.Context, data models.Upload) (models.Upload, error)
+	// ReleaseUpload undoes ClaimUpload when the file could not be stored, so the
+	// last chunk can be retried
+	ReleaseUpload(ctx context.Context, data models.Upload) error
+	// CompleteUpload links the claimed upload to the file made of its chunks and deletes them
+	CompleteUpload(ctx context.Context, data models.Upload, fileID uint, expiresAt time.Time) (models.Upload, error)
+	// DeleteUpload removes the upload and its chunks, the completed file stays
+	DeleteUpload(ctx context.Context, data models.Upload) error
+	// DeleteExpiredUploads removes up to limit expired uploads and returns how many
+	DeleteExpiredUploads(ctx context.Context, limit int) (int, error)
+}
+
+type uploadRepository struct {
+	db *gorm.DB
+}
+
+func NewUploadRepository(db *gorm.DB) UploadRepository {
+	return &uploadRepository{db: db}
+}
+
+func (r *uploadRepository) CreateUpload(ctx context.Context, data models.Upload) (response models.Upload, err error) {
+	response = data
+	err = conn(ctx, r.db).Create(&response).Error
+
+	return
+}
+
+func (r *uploadRepository) GetUpload(ctx context.Context, uploadID string) (data models.Upload, err error) {
+	err = conn(ctx, r.db).Preload("File").Where("upload_id = ?", uploadID).First(&data).Error
+
+	return
+}
+
+func (r *uploadRepository) AppendChunk(ctx context.Context, data models.Upload, key string, size int64, expiresAt time.Time) (response models.Upload, err error) {
+	result := conn(ctx, r.db).Model(&models.Upload{}).
+		Where("id = ? AND upload_offset = ? AND completed_at IS NULL", data.ID, data.UploadOffset).
+		UpdateColumns(map[string]interface{}{
+			"upload_offset": gorm.Expr("upload_offset + ?", size),
+			"chunks":        gorm.Expr("chunks || ?", key+"\n"),
+			"expires_at":    expiresAt,
+			"updated_at":    time.Now(),
+		})
+	if err = result.Error; err != nil {
+		return
+	}
+	if result.RowsAffected == 0 {
+		err = ErrUploadOffsetMismatch
+		return
+	}
+
+	err = conn(ctx, r.db).First(&response, data.ID).Error
+
+	return
+}
+
+func (r *uploadRepository) ClaimUpload(ctx context.Context, data models.Upload) (response models.Upload, err error) {
+	now := time.Now()
+	result := conn(ctx, r.db).Model(&models.Upload{}).
+		Where("id = ? AND completed_at IS NULL", data.ID).
+		UpdateColumns(map[string]interface{}{
+			"completed_at": now,
+			"updated_at":   now,
+		})
+	if err = result.Error; err != nil {
+		return
+	}
+	if result.RowsAffected == 0 {
+		err = ErrUploadClaimed
+		return
+	}
+
+	response = data
+	response.CompletedAt = &now
+
+	return
+}
+
+func (r *uploadRepository) ReleaseUpload(ctx context.Context, data models.Upload) error {
+	return conn(ctx, r.db).Model(&models.Upload{}).
+		Where("id = ? AND file_id IS NULL", data.ID).
+		UpdateColumns(map[string]interface{}{
+			"completed_at": nil,
+			"updated_at":   time.Now(),
+		}).Error
+}
+
+func (r *uploadRepository) CompleteUpload(ctx context.Context, data models.Upload, fileID uint, expiresAt time.Time) (response models.Upload, err error) {
+	now := time.Now()
+	err = conn(ctx, r.db).Model(&data).UpdateColumns(map[string]interface{}{
+		"file_id":      fileID,
+		"completed_at": now,
+		"chunks":       "",
+		"expires_at":   expiresAt,
+		"updated_at":   now,
+	}).Error
+	if err != nil {
+		return
+	}
+
+	// the file holds a copy now, a chunk left behind is only wasted space
+	for _, key := range UploadChunks(data) {
+		storage.Default.Delete(ctx, key)
+	}
+
+	err = conn(ctx, r.db).Preload("File").First(&response, data.ID).Error
+
+	return
+}
+
+func (r *uploadRepository) DeleteUpload(ctx context.Context, data models.Upload) error {
+	return deleteUpload(ctx, conn(ctx, r.db), data)
+}
+
+func (r *uploadRepository) DeleteExpiredUploads(ctx context.Context, limit int) (deleted int, err error) {
+	err = withTx(ctx, r.db, func(tx *gorm.DB) error {
+		var uploads []models.Upload
+		err := tx.Raw(`
+			SELECT * FROM uploads
+			WHERE expires_at < ?
+			ORDER BY expires_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED`,
+			time.Now(), limit,
+		).Scan(&uploads).Error
+		if err != nil {
+			return err
+		}
+
+		for _, upload := range uploads {
+			if err := deleteUpload(ctx, tx, upload); err != nil {
+				return err
+			}
+			deleted++
+		}
+
+		return nil
+	})
+
+	return
+}
+
+// deleteUpload removes the chunks before the row, like deleteFile
+func deleteUpload(ctx context.Context, tx *gorm.DB, data models.Upload) error {
+	for _, key := range UploadChunks(data) {
+		if err := storage.Default.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	return tx.Unscoped().Delete(&data).Error
+}
+
+// UploadChunks lists the object keys of the received chunks in order
+func UploadChunks(data models.Upload) []string {
+	return strings.Fields(data.Chunks)
+}
+
+// OpenUpload reads the received chunks of the upload one after the other
+func OpenUpload(ctx context.Context, data models.Upload) io.ReadCloser {
+	return &chunkReader{ctx: ctx, keys: UploadChunks(data)}
+}
+
+// chunkReader opens each chunk only when the previous one is exhausted
+type chunkReader struct {
+	ctx     context.Context
+	keys    []string
+	current io.ReadCloser
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.keys) == 0 {
+				return 0, io.EOF
+			}
+
+			body, _, err := storage.Default.Get(r.ctx, r.keys[0])
+			if err != nil {
+				return 0, err
+			}
+			r.current, r.keys = body, r.keys[1:]
+		}
+
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+
+		return n, err
+	}
+}
+
+func (r *chunkReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+
+	return r.current.Close()
+}
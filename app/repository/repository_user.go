@@ -1,16 +1,44 @@
 package repository
 
 import (
+	"context"
 	"project-name/app/middlewares"
 	"project-name/app/models"
 	"project-name/app/reqres"
+	"project-name/app/storage"
 	"project-name/app/utils"
-	"project-name/config"
 	"strconv"
 	"time"
+
+	"gorm.io/gorm"
 )
 
-func CreateUser(tglLahir time.Time, data reqres.UserRequest) (response models.User, err error) {
+// UserRepository reads and writes users. Every method runs in the transaction
+// carried by ctx when there is one, see UnitOfWork.
+type UserRepository interface {
+	// CreateUser and UpdateUser only accept an image uploaded by imageOwnerID, the
+	// user making the change, see ErrFileUnavailable
+	CreateUser(ctx context.Context, tglLahir time.Time, data reqres.UserRequest, imageOwnerID int) (models.User, error)
+	GetUsers(ctx context.Context, roleID int, param reqres.ReqPaging) (reqres.ResPaging, error)
+	GetAllUsers(ctx context.Context) ([]reqres.UserResponse, error)
+	GetUserByID(ctx context.Context, id int) (reqres.UserResponse, error)
+	GetUserProjection(ctx context.Context, id int, projection reqres.ReqProjection) (interface{}, error)
+	GetUserByIDPlain(ctx context.Context, id int) (models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+	GetUserByPhone(ctx context.Context, phone string) (models.User, error)
+	UpdateUser(ctx context.Context, data models.User, imageOwnerID int) (models.User, error)
+	DeleteUser(ctx context.Context, data models.User) (models.User, error)
+}
+
+type userRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+func (r *userRepository) CreateUser(ctx context.Context, tglLahir time.Time, data reqres.UserRequest, imageOwnerID int) (response models.User, err error) {
 	password := middlewares.BcryptPassword(data.Password)
 
 	response = models.User{
@@ -32,7 +60,13 @@ func CreateUser(tglLahir time.Time, data reqres.UserRequest) (response models.Us
 		PostalCode: data.PostalCode,
 	}
 
-	err = config.DB.Create(&response).Error
+	err = withTx(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Create(&response).Error; err != nil {
+			return err
+		}
+
+		return retainFile(tx, response.Image, imageOwnerID)
+	})
 
 	return
 }
@@ -42,7 +76,7 @@ func BuildUserResponse(data models.User) (response reqres.UserResponse) {
 		CustomGormModel: data.CustomGormModel,
 		Name:            data.Name,
 		Email:           data.Email,
-		Image:           config.LoadConfig().BaseUrl + "/" + config.LoadConfig().DirPath + data.Image,
+		Image:           storage.URL(data.Image),
 		Gender:          data.Gender,
 		TglLahir:        data.TglLahir,
 		Phone:           data.Phone,
@@ -50,96 +84,319 @@ func BuildUserResponse(data models.User) (response reqres.UserResponse) {
 		RoleID:          data.RoleID,
 		IsVerify:        data.IsVerify,
 		Status:          data.Status,
+		Prov:            data.Prov,
+		Kab:             data.Kab,
+		Kec:             data.Kec,
 		PostalCode:      data.PostalCode,
 		Kel:             data.Kel,
+		Role:            data.Role,
+		Province:        data.Province,
+		City:            data.City,
+		Subdistrict:     data.Subdistrict,
 	}
 
 	return
 }
 
-func GetUsers(roleID int, param reqres.ReqPaging) (data reqres.ResPaging) {
+// UserProjection lists the user response fields that can be picked with fields=
+// and the relations that can be loaded with expand=
+var UserProjection = utils.Projection{
+	Fields: map[string][]string{
+		"id":             {"id"},
+		"created_at":     {"created_at"},
+		"updated_at":     {"updated_at"},
+		"deleted_at":     {"deleted_at"},
+		"name":           {"name"},
+		"email":          {"email"},
+		"image":          {"image"},
+		"image_variants": {"image"},
+		"gender":         {"gender"},
+		"tgl_lahir":      {"tgl_lahir"},
+		"phone":          {"phone"},
+		"address":        {"address"},
+		"role_id":        {"role_id"},
+		"is_verify":      {"is_verify"},
+		"prov":           {"prov"},
+		"kab":            {"kab"},
+		"kec":            {"kec"},
+		"prov_name":      {"prov"},
+		"kab_name":       {"kab"},
+		"kec_name":       {"kec"},
+		"kel":            {"kel"},
+		"postal_code":    {"postal_code"},
+		"status":         {"status"},
+	},
+	Relations: map[string]utils.Relation{
+		"role":        {Preload: "Role", Columns: []string{"role_id"}},
+		"province":    {Preload: "Province", Columns: []string{"prov"}},
+		"city":        {Preload: "City", Columns: []string{"kab"}},
+		"subdistrict": {Preload: "Subdistrict", Columns: []string{"kec"}},
+	},
+	Always: []string{"id"},
+}
+
+// UserQuery lists the user columns that can be searched, sorted and filtered through the API
+var UserQuery = utils.QueryBuilder{
+	Searchable: []string{"name", "email", "phone"},
+	Sortable: map[string]string{
+		"id":         "id",
+		"name":       "name",
+		"email":      "email",
+		"phone":      "phone",
+		"gender":     "gender",
+		"tgl_lahir":  "tgl_lahir",
+		"role_id":    "role_id",
+		"status":     "status",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	Filterable: map[string]utils.FilterField{
+		"id":          {Column: "id", Type: utils.FieldInt},
+		"name":        {Column: "name", Type: utils.FieldString},
+		"email":       {Column: "email", Type: utils.FieldString},
+		"phone":       {Column: "phone", Type: utils.FieldString},
+		"gender":      {Column: "gender", Type: utils.FieldString},
+		"tgl_lahir":   {Column: "tgl_lahir", Type: utils.FieldTime},
+		"role_id":     {Column: "role_id", Type: utils.FieldInt},
+		"is_verify":   {Column: "is_verify", Type: utils.FieldBool},
+		"status":      {Column: "status", Type: utils.FieldInt},
+		"prov":        {Column: "prov", Type: utils.FieldInt},
+		"kab":         {Column: "kab", Type: utils.FieldInt},
+		"kec":         {Column: "kec", Type: utils.FieldInt},
+		"postal_code": {Column: "postal_code", Type: utils.FieldString},
+		"created_at":  {Column: "created_at", Type: utils.FieldTime},
+		"updated_at":  {Column: "updated_at", Type: utils.FieldTime},
+	},
+	DefaultSort: "id",
+}
+
+func (r *userRepository) GetUsers(ctx context.Context, roleID int, param reqres.ReqPaging) (data reqres.ResPaging, err error) {
 	var out []models.User
-	where := "deleted_at IS NULL"
 
+	// role_id and status predate filter[...] and are kept as shorthands
 	if roleID != 0 {
-		where += " AND role_id = " + strconv.Itoa(roleID)
-	}
-	if param.Search != "" {
-		where += " AND (name ILIKE '%" + param.Search + "%' OR email ILIKE '%" + param.Search + "%' OR phone ILIKE '%" + param.Search + "%')"
+		param.Filters = append(param.Filters, reqres.Filter{Field: "role_id", Value: strconv.Itoa(roleID)})
 	}
-	if param.Custom != "" {
-		where += " AND status = " + param.Custom.(string)
+	if status, ok := param.Custom.(string); ok && status != "" {
+		param.Filters = append(param.Filters, reqres.Filter{Field: "status", Value: status})
 	}
 
-	var modelTotal []models.User
-	var totalResult int64
-	config.DB.Model(&modelTotal).Where(where).Count(&totalResult)
-
-	var totalFiltered int64
-	config.DB.Model(&modelTotal).Where(where).Count(&totalFiltered)
+	if err = UserQuery.Validate(param); err != nil {
+		return
+	}
+	if err = UserProjection.Validate(param.Projection); err != nil {
+		return
+	}
 
-	config.DB.Where(where).Offset(param.Offset).Order(param.Sort + " " + param.Order).Limit(param.Limit).Find(&out)
+	page, err := UserQuery.Find(conn(ctx, r.db).Model(&models.User{}), param, &out,
+		UserProjection.Scope(param.Projection, UserQuery.SortColumn(param)))
+	if err != nil {
+		return
+	}
 
 	var responses []reqres.UserResponse
 	for _, response := range out {
 		responses = append(responses, BuildUserResponse(response))
 	}
+	if err = resolveRegionNames(conn(ctx, r.db), responses); err != nil {
+		return
+	}
+	if err = resolveImageVariants(conn(ctx, r.db), out, responses); err != nil {
+		return
+	}
+
+	projected, err := UserProjection.Project(param.Projection, responses)
+	if err != nil {
+		return
+	}
 
-	data = utils.PopulateResPaging(&param, responses, totalResult, totalFiltered)
+	data = utils.PopulatePageResPaging(&param, projected, page)
 
 	return
 }
 
-func GetAllUsers() (data []reqres.UserResponse, err error) {
+func (r *userRepository) GetAllUsers(ctx context.Context) (data []reqres.UserResponse, err error) {
 	var out []models.User
 
-	err = config.DB.Find(&out).Error
+	if err = conn(ctx, r.db).Find(&out).Error; err != nil {
+		return
+	}
 
 	for _, response := range out {
 		data = append(data, BuildUserResponse(response))
 	}
+	if err = resolveRegionNames(conn(ctx, r.db), data); err != nil {
+		return
+	}
+	err = resolveImageVariants(conn(ctx, r.db), out, data)
 
 	return
 }
 
-func GetUserByID(id int) (data reqres.UserResponse, err error) {
+func (r *userRepository) GetUserByID(ctx context.Context, id int) (data reqres.UserResponse, err error) {
 	var out models.User
 
-	err = config.DB.First(&out, id).Error
+	if err = conn(ctx, r.db).First(&out, id).Error; err != nil {
+		return
+	}
 
 	data = BuildUserResponse(out)
+	responses := []reqres.UserResponse{data}
+	if err = resolveRegionNames(conn(ctx, r.db), responses); err != nil {
+		return
+	}
+	if err = resolveImageVariants(conn(ctx, r.db), []models.User{out}, responses); err != nil {
+		return
+	}
+	data = responses[0]
+
+	return
+}
+
+// GetUserProjection returns the user with only the requested fields and expanded relations
+func (r *userRepository) GetUserProjection(ctx context.Context, id int, projection reqres.ReqProjection) (data interface{}, err error) {
+	if err = UserProjection.Validate(projection); err != nil {
+		return
+	}
+
+	var out models.User
+	if err = conn(ctx, r.db).Scopes(UserProjection.Scope(projection)).First(&out, id).Error; err != nil {
+		return
+	}
+
+	responses := []reqres.UserResponse{BuildUserResponse(out)}
+	if err = resolveRegionNames(conn(ctx, r.db), responses); err != nil {
+		return
+	}
+	if err = resolveImageVariants(conn(ctx, r.db), []models.User{out}, responses); err != nil {
+		return
+	}
+
+	data, err = UserProjection.Project(projection, responses[0])
 
 	return
 }
 
-func GetUserByIDPlain(id int) (data models.User, err error) {
-	err = config.DB.First(&data, id).Error
+// resolveRegionNames fills the region names of the responses in one query per level
+func resolveRegionNames(db *gorm.DB, responses []reqres.UserResponse) (err error) {
+	provIDs, kabIDs, kecIDs := map[int]bool{}, map[int]bool{}, map[int]bool{}
+	for _, response := range responses {
+		provIDs[response.Prov] = true
+		kabIDs[response.Kab] = true
+		kecIDs[response.Kec] = true
+	}
+
+	var provinces []models.Province
+	if err = db.Where("province_id IN ?", regionIDs(provIDs)).Find(&provinces).Error; err != nil {
+		return
+	}
+	var cities []models.City
+	if err = db.Where("city_id IN ?", regionIDs(kabIDs)).Find(&cities).Error; err != nil {
+		return
+	}
+	var subdistricts []models.Subdistrict
+	if err = db.Where("subdistrict_id IN ?", regionIDs(kecIDs)).Find(&subdistricts).Error; err != nil {
+		return
+	}
+
+	provNames, kabNames, kecNames := map[int]string{}, map[int]string{}, map[int]string{}
+	for _, p := range provinces {
+		provNames[p.ProvinceID] = p.ProvinceName
+	}
+	for _, c := range cities {
+		kabNames[c.CityID] = c.CityName
+	}
+	for _, s := range subdistricts {
+		kecNames[s.SubdistrictID] = s.SubdistrictName
+	}
+
+	for i := range responses {
+		responses[i].ProvName = provNames[responses[i].Prov]
+		responses[i].KabName = kabNames[responses[i].Kab]
+		responses[i].KecName = kecNames[responses[i].Kec]
+	}
 
 	return
 }
 
-func GetUserByEmail(email string) (data models.User, err error) {
-	err = config.DB.Where("email = ?", email).First(&data).Error
+// resolveImageVariants fills the variant URLs of the user images in one query,
+// responses[i] being built from users[i]
+func resolveImageVariants(db *gorm.DB, users []models.User, responses []reqres.UserResponse) error {
+	var keys []string
+	for _, user := range users {
+		if user.Image != "" {
+			keys = append(keys, user.Image)
+		}
+	}
+
+	urls, err := variantURLs(db, keys)
+	if err != nil {
+		return err
+	}
+
+	for i, user := range users {
+		responses[i].ImageVariants = urls[user.Image]
+	}
+
+	return nil
+}
+
+// regionIDs lists the set IDs, without the zero that marks an unset region. An
+// empty list matches nothing in an IN clause.
+func regionIDs(set map[int]bool) []int {
+	ids := []int{}
+	for id := range set {
+		if id != 0 {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+func (r *userRepository) GetUserByIDPlain(ctx context.Context, id int) (data models.User, err error) {
+	err = conn(ctx, r.db).First(&data, id).Error
+
+	return
+}
+
+func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (data models.User, err error) {
+	err = conn(ctx, r.db).Where("email = ?", email).First(&data).Error
 
 	return
 }
 
-func GetUserByPhone(phone string) (data models.User, err error) {
-	err = config.DB.Where("phone = ?", phone).First(&data).Error
+func (r *userRepository) GetUserByPhone(ctx context.Context, phone string) (data models.User, err error) {
+	err = conn(ctx, r.db).Where("phone = ?", phone).First(&data).Error
 
 	return
 }
 
-func UpdateUser(data models.User) (response models.User, err error) {
+func (r *userRepository) UpdateUser(ctx context.Context, data models.User, imageOwnerID int) (response models.User, err error) {
+	err = withTx(ctx, r.db, func(tx *gorm.DB) error {
+		var oldImage string
+		if err := tx.Model(&models.User{}).Where("id = ?", data.ID).Select("image").Scan(&oldImage).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Save(&data).Scan(&response).Error; err != nil {
+			return err
+		}
 
-	err = config.DB.Save(&data).Scan(&response).Error
+		return replaceFile(tx, oldImage, data.Image, imageOwnerID)
+	})
 
 	return
 }
 
-func DeleteUser(data models.User) (response models.User, err error) {
+func (r *userRepository) DeleteUser(ctx context.Context, data models.User) (response models.User, err error) {
+	err = withTx(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Delete(&data).Error; err != nil {
+			return err
+		}
 
-	err = config.DB.Unscoped().Delete(&data).Error
+		return releaseFile(tx, data.Image)
+	})
 
 	return
 }
@@ -1,6 +1,11 @@
 package reqres
 
-import validation "github.com/go-ozzo/ozzo-validation"
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+)
 
 type LoginRequest struct {
 	EmailOrPhone string `json:"emailorphone"`
@@ -16,15 +21,87 @@ func (request *LoginRequest) Validate() error {
 }
 
 type LoginResponse struct {
-	Token string       `json:"token"`
-	User  UserResponse `json:"user"`
+	Token        string       `json:"token"`
+	RefreshToken string       `json:"refresh_token"`
+	User         UserResponse `json:"user"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (request *RefreshTokenRequest) Validate() error {
+	return validation.ValidateStruct(
+		request,
+		validation.Field(&request.RefreshToken, validation.Required),
+	)
+}
+
+type RefreshTokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 type ForgotPasswordRequest struct {
 	Email string `json:"email"`
 }
 
+func (request *ForgotPasswordRequest) Validate() error {
+	return validation.ValidateStruct(
+		request,
+		validation.Field(&request.Email, validation.Required, is.Email),
+	)
+}
+
+type ResetPasswordRequest struct {
+	Token              string `json:"token"`
+	NewPassword        string `json:"new_password"`
+	NewPasswordConfirm string `json:"new_password_confirm"`
+}
+
+func (request *ResetPasswordRequest) Validate() error {
+	return validation.ValidateStruct(
+		request,
+		validation.Field(&request.Token, validation.Required),
+		validation.Field(&request.NewPassword, validation.Required),
+		validation.Field(&request.NewPasswordConfirm, validation.Required, validation.In(request.NewPassword).Error("must be same as new password")),
+	)
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+func (request *VerifyEmailRequest) Validate() error {
+	return validation.ValidateStruct(
+		request,
+		validation.Field(&request.Token, validation.Required),
+	)
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+func (request *ResendVerificationRequest) Validate() error {
+	return validation.ValidateStruct(
+		request,
+		validation.Field(&request.Email, validation.Required, is.Email),
+	)
+}
+
 type ChangePassword struct {
 	NewPassword        string `json:"new_password"`
 	NewPasswordConfirm string `json:"new_password_confirm"`
 }
+
+type SessionResponse struct {
+	SessionID  string    `json:"session_id"`
+	Device     string    `json:"device"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
@@ -0,0 +1,45 @@
+package reqres
+
+import (
+	"project-name/app/models"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type FileResponse struct {
+	models.CustomGormModel
+	Key      string `json:"key"`
+	OwnerID  int    `json:"owner_id"`
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	MimeType string `json:"mime_type"`
+	SHA256   string `json:"sha256"`
+	RefCount int    `json:"ref_count"`
+	// ScanStatus is quarantine, clean or infected, see models.FileScanQuarantine
+	ScanStatus string `json:"scan_status"`
+	ScanThreat string `json:"scan_threat,omitempty"`
+	URL        string `json:"url"`
+	// Variants holds the resized copies of an image by name
+	Variants map[string]FileVariantResponse `json:"variants,omitempty"`
+}
+
+type FileVariantResponse struct {
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+	Size     int64  `json:"size"`
+	MimeType string `json:"mime_type"`
+	URL      string `json:"url"`
+}
+
+type FileShareRequest struct {
+	// ExpiresIn is how many seconds the link stays valid, STORAGE_URL_TTL by default
+	ExpiresIn int    `json:"expires_in"`
+	Variant   string `json:"variant"`
+}
+
+func (request *FileShareRequest) Validate(maxTTL int) error {
+	return validation.ValidateStruct(
+		request,
+		validation.Field(&request.ExpiresIn, validation.Min(60), validation.Max(maxTTL)),
+	)
+}
@@ -0,0 +1,11 @@
+package reqres
+
+import "project-name/app/models"
+
+// OutboxMessageResponse shows who an email is for and what it is about, never its
+// body, which may carry password reset or verification links
+type OutboxMessageResponse struct {
+	models.OutboxMessage
+	To      []string `json:"to,omitempty"`
+	Subject string   `json:"subject,omitempty"`
+}
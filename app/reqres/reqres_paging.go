@@ -8,6 +8,29 @@ type ReqPaging struct {
 	Sort   string      `default:"ASC"`
 	Order  string      `default:"id"`
 	Custom interface{} `default:""`
+	// Filters parsed from filter[field]=value and filter[field][op]=value
+	Filters []Filter
+	Draw    int `default:"1"`
+	// CursorMode pages by keyset from Cursor instead of by offset
+	CursorMode bool
+	Cursor     string
+	// Count runs the total count query, it is skipped in cursor mode unless asked for
+	Count bool
+	// Projection picks the returned fields and expanded relations
+	Projection ReqProjection
+}
+
+// ReqProjection holds the fields= and expand= lists of a request
+type ReqProjection struct {
+	Fields []string
+	Expand []string
+}
+
+// Filter is one condition of a list query, all filters of a request must match
+type Filter struct {
+	Field    string
+	Operator string
+	Value    string
 }
 
 type ResPaging struct {
@@ -27,6 +50,8 @@ type ResPaging struct {
 	CurrentPage     int         `default:"1" json:"current_page"`
 	Sort            string      `default:"ASC" json:"sort"`
 	Order           string      `default:"id" json:"order"`
+	NextCursor      string      `json:"next_cursor,omitempty"`
+	PrevCursor      string      `json:"prev_cursor,omitempty"`
 }
 
 type GlobalIDNameResponse struct {
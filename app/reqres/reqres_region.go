@@ -0,0 +1,102 @@
+package reqres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"project-name/app/models"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"gorm.io/gorm"
+)
+
+// Region levels, from the widest
+const (
+	RegionProvince    = "province"
+	RegionCity        = "city"
+	RegionSubdistrict = "subdistrict"
+)
+
+// RegionResult is a province, city or subdistrict with the names of the regions it
+// belongs to, as returned by search and postal code lookups
+type RegionResult struct {
+	Level        string `json:"level"`
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	ProvinceID   int    `json:"province_id"`
+	ProvinceName string `json:"province_name"`
+	CityID       int    `json:"city_id,omitempty"`
+	CityName     string `json:"city_name,omitempty"`
+	PostalCode   string `json:"postal_code,omitempty"`
+}
+
+// RegionLookup finds regions by ID to validate addresses
+type RegionLookup interface {
+	GetProvinceByID(ctx context.Context, id int) (models.Province, error)
+	GetCityByID(ctx context.Context, id int) (models.City, error)
+	GetSubdistrictByID(ctx context.Context, id int) (models.Subdistrict, error)
+}
+
+// validateAddress adds the errors of the prov → kab → kec hierarchy to errs. Zero
+// means not set. Each level has to exist and belong to the level above it, which
+// is then required. Lookup failures other than not found are returned.
+func validateAddress(ctx context.Context, regions RegionLookup, prov, kab, kec int, errs validation.Errors) error {
+	if prov != 0 {
+		if _, err := regions.GetProvinceByID(ctx, prov); err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			errs["prov"] = fmt.Errorf("province %d does not exist", prov)
+		}
+	}
+
+	if kab != 0 {
+		city, err := regions.GetCityByID(ctx, kab)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			errs["kab"] = fmt.Errorf("city %d does not exist", kab)
+		case err != nil:
+			return err
+		case prov == 0:
+			errs["prov"] = errors.New("is required when kab is set")
+		case city.ProvinceID != prov:
+			errs["kab"] = fmt.Errorf("city %d is not in province %d", kab, prov)
+		}
+	}
+
+	if kec != 0 {
+		subdistrict, err := regions.GetSubdistrictByID(ctx, kec)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			errs["kec"] = fmt.Errorf("subdistrict %d does not exist", kec)
+		case err != nil:
+			return err
+		case kab == 0:
+			errs["kab"] = errors.New("is required when kec is set")
+		case subdistrict.CityID != kab:
+			errs["kec"] = fmt.Errorf("subdistrict %d is not in city %d", kec, kab)
+		}
+	}
+
+	return nil
+}
+
+// withAddress merges the address errors into the result of ValidateStruct
+func withAddress(err error, ctx context.Context, regions RegionLookup, prov, kab, kec int) error {
+	errs, ok := err.(validation.Errors)
+	if err != nil && !ok {
+		return err
+	}
+	if errs == nil {
+		errs = validation.Errors{}
+	}
+
+	if err := validateAddress(ctx, regions, prov, kab, kec, errs); err != nil {
+		return err
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
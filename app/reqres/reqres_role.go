@@ -0,0 +1,17 @@
+package reqres
+
+import validation "github.com/go-ozzo/ozzo-validation"
+
+type RoleRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	IsDefault   bool     `json:"is_default"`
+	Permissions []string `json:"permissions"`
+}
+
+func (request RoleRequest) Validate() error {
+	return validation.ValidateStruct(
+		&request,
+		validation.Field(&request.Name, validation.Required, validation.Length(1, 100)),
+	)
+}
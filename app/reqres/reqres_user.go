@@ -1,10 +1,12 @@
 package reqres
 
 import (
+	"context"
 	"project-name/app/models"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
 )
 
 type UserRequest struct {
@@ -25,13 +27,16 @@ type UserRequest struct {
 	PostalCode string `json:"postal_code"`
 }
 
-func (request UserRequest) Validate() error {
-	return validation.ValidateStruct(
+// Validate checks the required fields, and the address against the region tables
+func (request UserRequest) Validate(ctx context.Context, regions RegionLookup) error {
+	err := validation.ValidateStruct(
 		&request,
 		validation.Field(&request.Email, validation.Required),
 		validation.Field(&request.Password, validation.Required),
 		validation.Field(&request.Name, validation.Required),
 	)
+
+	return withAddress(err, ctx, regions, request.Prov, request.Kab, request.Kec)
 }
 
 type UserResponse struct {
@@ -45,11 +50,30 @@ type UserResponse struct {
 	Address    string    `json:"address"`
 	RoleID     int       `json:"role_id"`
 	IsVerify   bool      `json:"is_verify"`
+	Prov       int       `json:"prov"`
+	Kab        int       `json:"kab"`
+	Kec        int       `json:"kec"`
 	Kel        string    `json:"kel"`
 	PostalCode string    `json:"postal_code"`
 	Status     int       `json:"status"`
+
+	// Names of the Prov, Kab and Kec regions, empty when unset or unknown
+	ProvName string `json:"prov_name"`
+	KabName  string `json:"kab_name"`
+	KecName  string `json:"kec_name"`
+
+	// URLs of the resized copies of Image by variant name, e.g. thumb
+	ImageVariants map[string]string `json:"image_variants,omitempty"`
+
+	// Set only when asked for with expand=
+	Role        *models.Role        `json:"role,omitempty"`
+	Province    *models.Province    `json:"province,omitempty"`
+	City        *models.City        `json:"city,omitempty"`
+	Subdistrict *models.Subdistrict `json:"subdistrict,omitempty"`
 }
 
+// UserUpdateRequest changes only the fields that are sent, IsVerify is a pointer
+// so leaving it out keeps the current state
 type UserUpdateRequest struct {
 	Name       string `json:"name"`
 	Email      string `json:"email"`
@@ -59,10 +83,49 @@ type UserUpdateRequest struct {
 	Phone      string `json:"phone"`
 	Address    string `json:"address"`
 	RoleID     int    `json:"role_id"`
-	IsVerify   bool   `json:"is_verify"`
+	IsVerify   *bool  `json:"is_verify"`
+	Prov       int    `json:"prov"`
+	Kab        int    `json:"kab"`
+	Kec        int    `json:"kec"`
+	Kel        string `json:"kel"`
+	PostalCode string `json:"postal_code"`
+}
+
+// Validate checks the address against the region tables. Sending any of prov, kab
+// and kec replaces the whole address, so they are checked together.
+func (request UserUpdateRequest) Validate(ctx context.Context, regions RegionLookup) error {
+	return withAddress(nil, ctx, regions, request.Prov, request.Kab, request.Kec)
+}
+
+// MeUpdateRequest holds the profile fields a user may change on their own account.
+// Email, role, verification and image have their own flows.
+type MeUpdateRequest struct {
+	Name       string `json:"name"`
+	Gender     string `json:"gender"`
+	TglLahir   string `json:"tgl_lahir"`
+	Phone      string `json:"phone"`
+	Address    string `json:"address"`
 	Prov       int    `json:"prov"`
 	Kab        int    `json:"kab"`
 	Kec        int    `json:"kec"`
 	Kel        string `json:"kel"`
 	PostalCode string `json:"postal_code"`
 }
+
+// Validate checks the address against the region tables, like UserUpdateRequest
+func (request MeUpdateRequest) Validate(ctx context.Context, regions RegionLookup) error {
+	return withAddress(nil, ctx, regions, request.Prov, request.Kab, request.Kec)
+}
+
+type ChangeEmailRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func (request ChangeEmailRequest) Validate() error {
+	return validation.ValidateStruct(
+		&request,
+		validation.Field(&request.Email, validation.Required, is.Email),
+		validation.Field(&request.Password, validation.Required),
+	)
+}
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"project-name/app/controllers"
 	"project-name/app/middlewares"
+	"project-name/app/repository"
+	"project-name/app/session"
 	"project-name/config"
 	_ "project-name/docs" // For Swagger
 
@@ -42,20 +44,119 @@ func Init(app *echo.Echo) {
 		})
 	}
 
-	app.Static("/assets", "assets")
+	// only the scripts are public, uploads under DIR_PATH go through the handlers below
+	app.Static("/assets/js", "assets/js")
+
+	sessions := session.NewStore(config.DB)
+
+	userRepo := repository.NewUserRepository(config.DB)
+	authRepo := repository.NewAuthRepository(config.DB, sessions)
+	regionRepo := repository.NewRegionRepository(config.DB)
+	fileRepo := repository.NewFileRepository(config.DB)
+	uploadRepo := repository.NewUploadRepository(config.DB)
+	roleRepo := repository.NewRoleRepository(config.DB)
+	outboxRepo := repository.NewOutboxRepository(config.DB)
+	uow := repository.NewUnitOfWork(config.DB)
+
+	authController := controllers.NewAuthController(authRepo, userRepo, regionRepo, roleRepo, uow, sessions)
+	userController := controllers.NewUserController(userRepo, regionRepo, roleRepo)
+	meController := controllers.NewMeController(userRepo, authRepo, regionRepo, fileRepo)
+	regionController := controllers.NewRegionController(regionRepo)
+	fileController := controllers.NewFileController(fileRepo, roleRepo, sessions)
+	uploadController := controllers.NewUploadController(fileRepo, uploadRepo)
+	roleController := controllers.NewRoleController(roleRepo)
+	outboxController := controllers.NewOutboxController(outboxRepo)
+
+	authn := middlewares.Auth(sessions)
+	authz := middlewares.NewAuthorizer(roleRepo)
+
+	// signed links carry neither the token nor the API key, DownloadFile checks them
+	app.GET("/v1/files/:id/download", fileController.DownloadFile)
+	app.GET("/storage/*", fileController.ServeStorageObject)
 
 	api := app.Group("/v1", middlewares.StripHTMLMiddleware, middlewares.CheckAPIKey())
 	{
 		auth := api.Group("/auth")
 		{
-			auth.POST("/login/user", controllers.LoginUser)
-			auth.POST("/login/admin", controllers.LoginAdmin)
-			auth.POST("/register", controllers.Register)
-			auth.POST("/forgot-password", controllers.ForgotPassword)
-			auth.POST("/email-verify", controllers.SendEmailVerifyEmail, middlewares.Auth())
-			auth.PUT("/activate-account/:id", controllers.AktivateAccount, middlewares.Auth())
-			auth.PUT("/change-password-login", controllers.ChangePasswordLogin, middlewares.Auth())
-			auth.PUT("/reset-password/:id", controllers.ResetPassword)
+			auth.POST("/login/user", authController.LoginUser)
+			auth.POST("/login/admin", authController.LoginAdmin)
+			auth.POST("/register", authController.Register)
+			auth.POST("/refresh", authController.RefreshToken)
+			auth.POST("/forgot-password", authController.ForgotPassword)
+			auth.POST("/email-verify", authController.SendEmailVerifyEmail, authn)
+			auth.POST("/resend-verification", authController.ResendVerificationEmail)
+			auth.POST("/verify-email", authController.VerifyEmail)
+			auth.PUT("/change-password-login", authController.ChangePasswordLogin, authn)
+			auth.PUT("/reset-password", authController.ResetPassword)
+			auth.POST("/logout", authController.Logout, authn)
+			auth.POST("/logout-all", authController.LogoutAll, authn)
+			auth.GET("/sessions", authController.GetSessions, authn)
+			auth.DELETE("/sessions/:id", authController.RevokeSession, authn)
+		}
+
+		me := api.Group("/me", authn)
+		{
+			me.GET("", meController.GetMe)
+			me.PATCH("", meController.UpdateMe)
+			me.POST("/email", meController.ChangeMyEmail)
+			me.POST("/avatar", meController.UploadMyAvatar)
+		}
+
+		user := api.Group("/user", authn)
+		{
+			user.GET("", userController.GetUsers, authz.RequirePermission("user.read"))
+			user.GET("/all", userController.GetAllUsers, authz.RequirePermission("user.read"))
+			user.GET("/:id", userController.GetUserByID, authz.RequirePermission("user.read"))
+			user.POST("", userController.CreateUser, authz.RequirePermission("user.create"))
+			user.PUT("/:id", userController.UpdateUser, authz.RequirePermission("user.update"))
+			user.DELETE("/:id", userController.DeleteUser, authz.RequirePermission("user.delete"))
+		}
+
+		file := api.Group("/file", authn, authz.RequirePermission("file.upload"))
+		{
+			file.GET("", fileController.GetMyFiles)
+			file.DELETE("/:id", fileController.DeleteMyFile)
+			file.POST("/upload", fileController.UploadFile)
+			file.POST("/upload-multiple", fileController.UploadMultipleFiles)
+
+			tus := file.Group("/tus", middlewares.TusResumable(config.LoadConfig().UploadMaxSize))
+			{
+				tus.POST("", uploadController.CreateUpload)
+				tus.HEAD("/:id", uploadController.GetUploadOffset)
+				tus.PATCH("/:id", uploadController.PatchUpload)
+				tus.DELETE("/:id", uploadController.DeleteUpload)
+			}
+		}
+
+		api.POST("/files/:id/share", fileController.ShareFile, authn)
+
+		role := api.Group("/role", authn)
+		{
+			role.GET("", roleController.GetRoles, authz.RequirePermission("role.read"))
+			role.GET("/all", roleController.GetAllRoles, authz.RequirePermission("role.read"))
+			role.GET("/:id", roleController.GetRoleByID, authz.RequirePermission("role.read"))
+			role.POST("", roleController.CreateRole, authz.RequirePermission("role.create"))
+			role.PUT("/:id", roleController.UpdateRole, authz.RequirePermission("role.update"))
+			role.DELETE("/:id", roleController.DeleteRole, authz.RequirePermission("role.delete"))
+		}
+
+		region := api.Group("/region", middlewares.CacheControl(config.LoadConfig().RegionCacheMaxAge))
+		{
+			region.GET("/province", regionController.GetProvinces)
+			region.GET("/city/:prov_id", regionController.GetCities)
+			region.GET("/subdistrict/:city_id", regionController.GetSubdistricts)
+			region.GET("/search", regionController.SearchRegions)
+			region.GET("/postal-code/:code", regionController.GetRegionsByPostalCode)
+		}
+
+		api.GET("/permission/all", roleController.GetAllPermissions, authn, authz.RequirePermission("role.read"))
+
+		outbox := api.Group("/outbox", authn, authz.RequirePermission("outbox.manage"))
+		{
+			outbox.GET("", outboxController.GetOutboxMessages)
+			outbox.GET("/:id", outboxController.GetOutboxMessageByID)
+			outbox.POST("/retry-dead", outboxController.RetryDeadOutboxMessages)
+			outbox.POST("/:id/retry", outboxController.RetryOutboxMessage)
 		}
 
 	}
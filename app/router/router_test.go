@@ -0,0 +1,55 @@
+package router
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+var swaggerParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// TestSwaggerPathsAreServed fails when an endpoint is documented in
+// docs/swagger.json but not registered in Init
+func TestSwaggerPathsAreServed(t *testing.T) {
+	// Init parses the templates relative to the project root
+	wd, _ := os.Getwd()
+	if err := os.Chdir(filepath.Join("..", "..")); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	raw, err := os.ReadFile(filepath.Join("docs", "swagger.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	app := echo.New()
+	Init(app)
+
+	served := map[string]bool{}
+	for _, route := range app.Routes() {
+		served[route.Method+" "+route.Path] = true
+	}
+
+	for path, operations := range spec.Paths {
+		echoPath := swaggerParam.ReplaceAllString(path, ":$1")
+		for method := range operations {
+			key := strings.ToUpper(method) + " " + echoPath
+			if !served[key] {
+				t.Errorf("%s %s is documented but not served", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
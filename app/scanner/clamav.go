@@ -0,0 +1,130 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// ClamAV scans files with clamd, streaming them through the INSTREAM command
+type ClamAV struct {
+	Network string
+	Address string
+	// Timeout bounds every read and write on the connection, not the whole scan
+	Timeout time.Duration
+	// ChunkSize is the size of the INSTREAM chunks, clamd's StreamMaxLength still
+	// limits the whole file
+	ChunkSize int
+}
+
+// NewClamAV connects to clamd at address, either unix:/path/to/clamd.sock or
+// host:port with an optional tcp: prefix
+func NewClamAV(address string, timeout time.Duration) *ClamAV {
+	network := "tcp"
+	if path, ok := strings.CutPrefix(address, "unix:"); ok {
+		network, address = "unix", path
+	}
+
+	return &ClamAV{
+		Network:   network,
+		Address:   strings.TrimPrefix(address, "tcp:"),
+		Timeout:   timeout,
+		ChunkSize: 64 << 10,
+	}
+}
+
+func (c *ClamAV) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	dialer := net.Dialer{Timeout: c.Timeout}
+	conn, err := dialer.DialContext(ctx, c.Network, c.Address)
+	if err != nil {
+		return Result{}, fmt.Errorf("clamd: %w", err)
+	}
+	defer conn.Close()
+
+	// the context cancels the scan like it cancels the dial
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	if err := c.stream(conn, r); err != nil {
+		// clamd hangs up once a file is over StreamMaxLength, its reply says so
+		if reply, replyErr := c.reply(conn); replyErr == nil && reply != "" {
+			return parseReply(reply)
+		}
+		return Result{}, fmt.Errorf("clamd: %w", err)
+	}
+
+	reply, err := c.reply(conn)
+	if err != nil {
+		return Result{}, fmt.Errorf("clamd: %w", err)
+	}
+
+	return parseReply(reply)
+}
+
+// stream sends r as length prefixed chunks, ended by an empty one
+func (c *ClamAV) stream(conn net.Conn, r io.Reader) error {
+	if err := c.write(conn, []byte("zINSTREAM\x00")); err != nil {
+		return err
+	}
+
+	chunk := make([]byte, 4+c.ChunkSize)
+	for {
+		n, err := io.ReadFull(r, chunk[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(chunk, uint32(n))
+			if err := c.write(conn, chunk[:4+n]); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.write(conn, []byte{0, 0, 0, 0})
+}
+
+func (c *ClamAV) write(conn net.Conn, data []byte) error {
+	if c.Timeout > 0 {
+		conn.SetWriteDeadline(time.Now().Add(c.Timeout))
+	}
+	_, err := conn.Write(data)
+
+	return err
+}
+
+// reply reads the null terminated answer of a z-prefixed command
+func (c *ClamAV) reply(conn net.Conn) (string, error) {
+	if c.Timeout > 0 {
+		conn.SetReadDeadline(time.Now().Add(c.Timeout))
+	}
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !(err == io.EOF && reply != "") {
+		return "", err
+	}
+
+	return strings.TrimSpace(strings.TrimSuffix(reply, "\x00")), nil
+}
+
+// parseReply reads "stream: OK", "stream: <threat> FOUND" or "<message> ERROR"
+func parseReply(reply string) (Result, error) {
+	verdict := strings.TrimPrefix(reply, "stream: ")
+
+	switch {
+	case verdict == "OK":
+		return Result{}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return Result{Infected: true, Threat: strings.TrimSuffix(verdict, " FOUND")}, nil
+	default:
+		return Result{}, errors.New("clamd: " + verdict)
+	}
+}
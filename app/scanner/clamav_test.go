@@ -0,0 +1,102 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClamd answers INSTREAM like clamd, reporting files containing EICAR and
+// refusing those over maxLength
+func fakeClamd(t *testing.T, maxLength int) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveClamd(conn, maxLength)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func serveClamd(conn net.Conn, maxLength int) {
+	defer conn.Close()
+
+	command := make([]byte, len("zINSTREAM\x00"))
+	if _, err := io.ReadFull(conn, command); err != nil || string(command) != "zINSTREAM\x00" {
+		conn.Write([]byte("UNKNOWN COMMAND\x00"))
+		return
+	}
+
+	var data []byte
+	for {
+		var size uint32
+		if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+			return
+		}
+		if size == 0 {
+			break
+		}
+		chunk := make([]byte, size)
+		if _, err := io.ReadFull(conn, chunk); err != nil {
+			return
+		}
+		data = append(data, chunk...)
+		if len(data) > maxLength {
+			conn.Write([]byte("INSTREAM size limit exceeded. ERROR\x00"))
+			return
+		}
+	}
+
+	if bytes.Contains(data, []byte("EICAR")) {
+		conn.Write([]byte("stream: Eicar-Test-Signature FOUND\x00"))
+		return
+	}
+	conn.Write([]byte("stream: OK\x00"))
+}
+
+func TestClamAV(t *testing.T) {
+	clamd := NewClamAV("tcp:"+fakeClamd(t, 1<<20), 5*time.Second)
+	clamd.ChunkSize = 4 // the payload spans chunks
+	ctx := context.Background()
+
+	result, err := clamd.Scan(ctx, strings.NewReader("%PDF-1.7 harmless"))
+	if err != nil || result.Infected {
+		t.Fatalf("clean file: %+v, %v", result, err)
+	}
+
+	result, err = clamd.Scan(ctx, strings.NewReader("%PDF-1.7 X5O!P%@AP[4\\PZX54(P^)7CC)7}$EICAR"))
+	if err != nil || !result.Infected || result.Threat != "Eicar-Test-Signature" {
+		t.Fatalf("infected file: %+v, %v", result, err)
+	}
+
+	clamd.ChunkSize = 64 << 10
+	result, err = clamd.Scan(ctx, bytes.NewReader(make([]byte, 2<<20)))
+	if err == nil || !strings.Contains(err.Error(), "size limit exceeded") {
+		t.Fatalf("oversized file: %+v, %v", result, err)
+	}
+}
+
+func TestClamAVUnavailable(t *testing.T) {
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	address := listener.Addr().String()
+	listener.Close()
+
+	if _, err := NewClamAV(address, time.Second).Scan(context.Background(), strings.NewReader("x")); err == nil {
+		t.Fatal("scan without clamd passed")
+	}
+}
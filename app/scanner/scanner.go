@@ -0,0 +1,46 @@
+package scanner
+
+import (
+	"context"
+	"io"
+	"log"
+	"project-name/config"
+	"time"
+)
+
+// Result is the verdict on a scanned file
+type Result struct {
+	Infected bool
+	// Threat names what was found, e.g. Eicar-Test-Signature
+	Threat string
+}
+
+// Scanner checks uploaded files for malware before they are kept
+type Scanner interface {
+	// Scan reads r to the end. An error means no verdict, not an infected file.
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// Noop passes every file, it is the default when no scanner is configured
+type Noop struct{}
+
+func (Noop) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	return Result{}, nil
+}
+
+// Default is the scanner selected by SCANNER_DRIVER
+var Default Scanner = Noop{}
+
+// Init sets Default from the configuration
+func Init() {
+	cfg := config.LoadConfig()
+
+	switch cfg.ScannerDriver {
+	case "", "NONE":
+		Default = Noop{}
+	case "CLAMAV":
+		Default = NewClamAV(cfg.ClamAVAddress, time.Duration(cfg.ClamAVTimeout)*time.Second)
+	default:
+		log.Panicf("Unknown SCANNER_DRIVER %q", cfg.ScannerDriver)
+	}
+}
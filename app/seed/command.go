@@ -0,0 +1,55 @@
+package seed
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"gorm.io/gorm"
+)
+
+// Command runs the seed subcommand. Flags override the admin options read from the
+// environment; the remaining arguments name the seeders to run, all by default.
+// connect is not called when only listing the seeders.
+func Command(args []string, connect func() *gorm.DB, opts Options, out io.Writer) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.StringVar(&opts.AdminName, "admin-name", opts.AdminName, "name of the first admin")
+	flags.StringVar(&opts.AdminEmail, "admin-email", opts.AdminEmail, "email of the first admin")
+	flags.StringVar(&opts.AdminPassword, "admin-password", opts.AdminPassword, "password of the first admin")
+	force := flags.Bool("force", false, "run seeders that are recorded as applied again")
+	list := flags.Bool("list", false, "list the seeders and exit")
+	flags.Usage = func() {
+		fmt.Fprintln(out, "usage: seed [flags] [seeder ...]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *list {
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		for _, seeder := range Registry {
+			fmt.Fprintf(w, "%s\t%s\n", seeder.Name, seeder.Description)
+		}
+		return w.Flush()
+	}
+
+	seeders, err := Lookup(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	results, err := Run(context.Background(), connect(), seeders, opts, *force)
+	for _, result := range results {
+		if result.Reason != "" {
+			fmt.Fprintf(out, "%s: %s (%s)\n", result.Name, result.Status, result.Reason)
+		} else {
+			fmt.Fprintf(out, "%s: %s\n", result.Name, result.Status)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,148 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// lockKey identifies the Postgres advisory lock held while seeding
+const lockKey int64 = 7_270_031_602
+
+// Options are the inputs seeders read besides the database
+type Options struct {
+	// SQL holds the region seed files
+	SQL           fs.FS
+	AdminName     string
+	AdminEmail    string
+	AdminPassword string
+}
+
+// Seeder loads one set of data
+type Seeder struct {
+	Name        string
+	Description string
+	// Repeatable seeders are idempotent on their own and run on every seed, e.g. to
+	// add permissions introduced since the last run
+	Repeatable bool
+	Run        func(ctx context.Context, tx *gorm.DB, opts Options) error
+}
+
+// Result is what happened to one seeder
+type Result struct {
+	Name   string
+	Status string
+	Reason string
+}
+
+const (
+	StatusApplied        = "applied"
+	StatusAlreadyApplied = "already applied"
+	StatusSkipped        = "skipped"
+)
+
+type seedRecord struct {
+	Name      string `gorm:"primaryKey"`
+	AppliedAt time.Time
+}
+
+func (seedRecord) TableName() string {
+	return "seeds"
+}
+
+// Lookup returns the registered seeders with the given names, in registry order.
+// Without names every seeder is returned.
+func Lookup(names []string) (seeders []Seeder, err error) {
+	if len(names) == 0 {
+		return Registry, nil
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	for _, seeder := range Registry {
+		if wanted[seeder.Name] {
+			seeders = append(seeders, seeder)
+			delete(wanted, seeder.Name)
+		}
+	}
+
+	for name := range wanted {
+		err = fmt.Errorf("unknown seeder %q", name)
+		return
+	}
+
+	return
+}
+
+// Run applies seeders in order, each in its own transaction, and records the ones
+// that succeed. Recorded seeders are not run again unless they are repeatable or
+// force is set.
+func Run(ctx context.Context, db *gorm.DB, seeders []Seeder, opts Options, force bool) (results []Result, err error) {
+	err = db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", lockKey).Error; err != nil {
+			return err
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", lockKey)
+
+		if !conn.Migrator().HasTable(&seedRecord{}) {
+			return errors.New("seeds table does not exist, run `migrate up` first")
+		}
+
+		for _, seeder := range seeders {
+			result := Result{Name: seeder.Name, Status: StatusApplied}
+
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				var count int64
+				if err := tx.Model(&seedRecord{}).Where("name = ?", seeder.Name).Count(&count).Error; err != nil {
+					return err
+				}
+				if count > 0 && !seeder.Repeatable && !force {
+					result.Status = StatusAlreadyApplied
+					return nil
+				}
+
+				if err := seeder.Run(ctx, tx, opts); err != nil {
+					return err
+				}
+
+				return tx.Save(&seedRecord{Name: seeder.Name, AppliedAt: time.Now()}).Error
+			})
+
+			var skip skipError
+			if errors.As(err, &skip) {
+				result.Status, result.Reason = StatusSkipped, skip.reason
+				err = nil
+			}
+			if err != nil {
+				return fmt.Errorf("seed %s: %w", seeder.Name, err)
+			}
+
+			results = append(results, result)
+		}
+
+		return nil
+	})
+
+	return
+}
+
+// skipError stops a seeder that has nothing to do this run. The seeder is not
+// recorded, so it runs again next time.
+type skipError struct {
+	reason string
+}
+
+func (e skipError) Error() string {
+	return "skipped: " + e.reason
+}
+
+func skip(format string, args ...interface{}) error {
+	return skipError{reason: fmt.Sprintf(format, args...)}
+}
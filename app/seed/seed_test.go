@@ -0,0 +1,203 @@
+package seed
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"project-name/app/database/databasetest"
+	"project-name/app/migrate"
+	"project-name/migrations"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func names(seeders []Seeder) (out []string) {
+	for _, seeder := range seeders {
+		out = append(out, seeder.Name)
+	}
+	return
+}
+
+func TestLookup(t *testing.T) {
+	all, err := Lookup(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names(all), names(Registry)) {
+		t.Errorf("without names = %v", names(all))
+	}
+
+	// asked for out of order, run in registry order
+	seeders, err := Lookup([]string{"admin", "cities", "provinces", "cities"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := names(seeders); !reflect.DeepEqual(got, []string{"provinces", "cities", "admin"}) {
+		t.Errorf("seeders = %v", got)
+	}
+
+	if _, err := Lookup([]string{"roles", "villages"}); err == nil || !strings.Contains(err.Error(), `"villages"`) {
+		t.Errorf("unknown seeder: err = %v", err)
+	}
+}
+
+func TestCommandWithoutDatabase(t *testing.T) {
+	connect := func() *gorm.DB {
+		t.Fatal("connected to the database")
+		return nil
+	}
+
+	var out bytes.Buffer
+	if err := Command([]string{"--list"}, connect, Options{}, &out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(Registry) || !strings.HasPrefix(lines[0], "roles ") || !strings.Contains(lines[0], "default roles and permissions") {
+		t.Errorf("list = %q", out.String())
+	}
+
+	if err := Command([]string{"villages"}, connect, Options{}, &out); err == nil {
+		t.Error("ran an unknown seeder")
+	}
+}
+
+// testSeeders count their runs in runs
+func testSeeders(runs map[string]int) []Seeder {
+	done := func(ctx context.Context, tx *gorm.DB, opts Options) error {
+		return nil
+	}
+	seeder := func(name string, repeatable bool, run func(ctx context.Context, tx *gorm.DB, opts Options) error) Seeder {
+		return Seeder{Name: name, Repeatable: repeatable, Run: func(ctx context.Context, tx *gorm.DB, opts Options) error {
+			runs[name]++
+			return run(ctx, tx, opts)
+		}}
+	}
+
+	return []Seeder{
+		seeder("once", false, done),
+		seeder("repeatable", true, done),
+		seeder("nothing to do", false, func(ctx context.Context, tx *gorm.DB, opts Options) error {
+			return skip("no admin email")
+		}),
+	}
+}
+
+func statuses(results []Result) (out []string) {
+	for _, result := range results {
+		out = append(out, result.Name+": "+result.Status)
+	}
+	return
+}
+
+func recorded(t *testing.T, db *gorm.DB) (out []string) {
+	t.Helper()
+
+	if err := db.Model(&seedRecord{}).Order("name").Pluck("name", &out).Error; err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func migratedDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db := databasetest.Open(t)
+	migrator, err := migrate.New(db, migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestRunRecordsApplied(t *testing.T) {
+	db := migratedDB(t)
+	ctx := context.Background()
+	runs := map[string]int{}
+	seeders := testSeeders(runs)
+
+	results, err := Run(ctx, db, seeders, Options{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := statuses(results); !reflect.DeepEqual(got, []string{"once: applied", "repeatable: applied", "nothing to do: skipped"}) {
+		t.Errorf("first run = %v", got)
+	}
+	if results[2].Reason != "no admin email" {
+		t.Errorf("reason = %q", results[2].Reason)
+	}
+	// skipped seeders are not recorded so they run again next time
+	if got := recorded(t, db); !reflect.DeepEqual(got, []string{"once", "repeatable"}) {
+		t.Errorf("recorded = %v", got)
+	}
+
+	results, err = Run(ctx, db, seeders, Options{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := statuses(results); !reflect.DeepEqual(got, []string{"once: already applied", "repeatable: applied", "nothing to do: skipped"}) {
+		t.Errorf("second run = %v", got)
+	}
+	if want := map[string]int{"once": 1, "repeatable": 2, "nothing to do": 2}; !reflect.DeepEqual(runs, want) {
+		t.Errorf("runs = %v, want %v", runs, want)
+	}
+
+	results, err = Run(ctx, db, seeders, Options{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := statuses(results); !reflect.DeepEqual(got, []string{"once: applied", "repeatable: applied", "nothing to do: skipped"}) {
+		t.Errorf("forced run = %v", got)
+	}
+	if runs["once"] != 2 {
+		t.Errorf("force ran once %d times", runs["once"])
+	}
+}
+
+func TestRunStopsAtFailure(t *testing.T) {
+	db := migratedDB(t)
+	ctx := context.Background()
+	failure := errors.New("region file missing")
+
+	results, err := Run(ctx, db, []Seeder{
+		{Name: "first", Run: func(ctx context.Context, tx *gorm.DB, opts Options) error { return nil }},
+		{Name: "broken", Run: func(ctx context.Context, tx *gorm.DB, opts Options) error {
+			if err := tx.Exec(`CREATE TABLE "half_done" ("id" int)`).Error; err != nil {
+				return err
+			}
+			return failure
+		}},
+		{Name: "after", Run: func(ctx context.Context, tx *gorm.DB, opts Options) error {
+			t.Error("ran a seeder after the failure")
+			return nil
+		}},
+	}, Options{}, false)
+
+	if !errors.Is(err, failure) || !strings.Contains(err.Error(), "seed broken") {
+		t.Fatalf("err = %v", err)
+	}
+	if got := statuses(results); !reflect.DeepEqual(got, []string{"first: applied"}) {
+		t.Errorf("results = %v", got)
+	}
+	if got := recorded(t, db); !reflect.DeepEqual(got, []string{"first"}) {
+		t.Errorf("recorded = %v", got)
+	}
+	if db.Migrator().HasTable("half_done") {
+		t.Error("the failed seeder was not rolled back")
+	}
+}
+
+func TestRunWithoutSeedsTable(t *testing.T) {
+	db := databasetest.Open(t)
+
+	_, err := Run(context.Background(), db, testSeeders(map[string]int{}), Options{}, false)
+	if err == nil || !strings.Contains(err.Error(), "seeds table does not exist") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"project-name/app/models"
+	"project-name/app/repository"
+	"project-name/app/reqres"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"gorm.io/gorm"
+)
+
+// Registry lists every seeder in the order they run. Regions go parent first and
+// the admin comes after the roles it is given.
+var Registry = []Seeder{
+	{
+		Name:        "roles",
+		Description: "default roles and permissions",
+		Repeatable:  true,
+		Run: func(ctx context.Context, tx *gorm.DB, opts Options) error {
+			return repository.SeedRolesAndPermissions(tx)
+		},
+	},
+	regionSeeder("provinces", "province.sql"),
+	regionSeeder("cities", "city.sql"),
+	regionSeeder("subdistricts", "subdistrict.sql"),
+	{
+		Name:        "admin",
+		Description: "first super admin user",
+		Run:         seedAdmin,
+	},
+}
+
+// regionSeeder loads file into table. A table that already has rows was loaded by
+// hand before seeds were recorded, so it is only marked as applied.
+func regionSeeder(table, file string) Seeder {
+	return Seeder{
+		Name:        table,
+		Description: "region data from " + file,
+		Run: func(ctx context.Context, tx *gorm.DB, opts Options) error {
+			var count int64
+			if err := tx.Table(table).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return nil
+			}
+
+			content, err := fs.ReadFile(opts.SQL, file)
+			if err != nil {
+				return err
+			}
+
+			return tx.Exec(string(content)).Error
+		},
+	}
+}
+
+// seedAdmin creates a verified user with the full access role. It is skipped until
+// an email and password are given, and when a super admin exists already.
+func seedAdmin(ctx context.Context, tx *gorm.DB, opts Options) error {
+	if opts.AdminEmail == "" || opts.AdminPassword == "" {
+		return skip("admin email and password are not set")
+	}
+
+	name := opts.AdminName
+	if name == "" {
+		name = "Admin"
+	}
+
+	err := validation.Errors{
+		"email":    validation.Validate(opts.AdminEmail, is.Email),
+		"password": validation.Validate(opts.AdminPassword, validation.Length(8, 0)),
+	}.Filter()
+	if err != nil {
+		return err
+	}
+
+	var roleID uint
+	for _, role := range repository.DefaultRoles {
+		// the role without a permission list is granted every permission
+		if role.Permissions == nil {
+			roleID = role.ID
+			break
+		}
+	}
+
+	var admins int64
+	if err := tx.Model(&models.User{}).Where("role_id = ?", roleID).Count(&admins).Error; err != nil {
+		return err
+	}
+	if admins > 0 {
+		return skip("a super admin exists already")
+	}
+
+	users := repository.NewUserRepository(tx)
+
+	_, err = users.GetUserByEmail(ctx, opts.AdminEmail)
+	if err == nil {
+		return fmt.Errorf("user %s exists already and is not a super admin", opts.AdminEmail)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	admin, err := users.CreateUser(ctx, time.Time{}, reqres.UserRequest{
+		Name:     name,
+		Email:    opts.AdminEmail,
+		Password: opts.AdminPassword,
+		RoleID:   int(roleID),
+	}, 0)
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&admin).Update("status", 1).Error
+}
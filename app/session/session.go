@@ -0,0 +1,153 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"project-name/app/models"
+	"project-name/app/utils"
+	"project-name/config"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrSessionNotFound = errors.New("session not found or revoked")
+
+// touchInterval limits how often LastSeenAt is written back on authenticated requests
+const touchInterval = time.Minute
+
+// Store persists sessions. NewStore picks Redis when SESSION_STORE=redis, the
+// database otherwise.
+type Store interface {
+	Save(ctx context.Context, data models.Session) error
+	Get(ctx context.Context, sessionID string) (models.Session, error)
+	ListByUser(ctx context.Context, userID int) ([]models.Session, error)
+	Delete(ctx context.Context, sessionID string) error
+}
+
+// NewStore selects the store from config, the database store keeps its sessions in db
+func NewStore(db *gorm.DB) Store {
+	if config.LoadConfig().SessionStore == "REDIS" && config.RC != nil {
+		return NewRedisStore(config.RC)
+	}
+	return NewDatabaseStore(db)
+}
+
+// Create starts a new session for the user
+func Create(ctx context.Context, store Store, userID int, device, userAgent, ip string) (data models.Session, err error) {
+	sessionID, err := utils.GenerateSecureToken(24)
+	if err != nil {
+		return
+	}
+
+	if device == "" {
+		device = userAgent
+	}
+
+	now := time.Now()
+	data = models.Session{
+		SessionID:  sessionID,
+		UserID:     userID,
+		Device:     device,
+		UserAgent:  userAgent,
+		IPAddress:  ip,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(time.Duration(config.LoadConfig().JwtRefreshTokenTTL) * time.Minute),
+	}
+	data.CreatedAt = now
+	data.UpdatedAt = now
+
+	err = store.Save(ctx, data)
+
+	return
+}
+
+// Validate checks that the session is still active and belongs to the user,
+// and records the request as the session's last activity
+func Validate(ctx context.Context, store Store, sessionID string, userID int, ip string) (data models.Session, err error) {
+	data, err = store.Get(ctx, sessionID)
+	if err != nil {
+		return
+	}
+	if data.UserID != userID || data.ExpiresAt.Before(time.Now()) {
+		err = ErrSessionNotFound
+		return
+	}
+
+	if time.Since(data.LastSeenAt) > touchInterval || data.IPAddress != ip {
+		data.LastSeenAt = time.Now()
+		data.IPAddress = ip
+		err = store.Save(ctx, data)
+	}
+
+	return
+}
+
+// Extend pushes the expiry of the session forward, called on every token refresh
+func Extend(ctx context.Context, store Store, sessionID string) error {
+	data, err := store.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	data.LastSeenAt = time.Now()
+	data.ExpiresAt = data.LastSeenAt.Add(time.Duration(config.LoadConfig().JwtRefreshTokenTTL) * time.Minute)
+
+	return store.Save(ctx, data)
+}
+
+// ListActive returns the user's sessions that have not expired
+func ListActive(ctx context.Context, store Store, userID int) (data []models.Session, err error) {
+	all, err := store.ListByUser(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	for _, s := range all {
+		if s.ExpiresAt.After(now) {
+			data = append(data, s)
+		}
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].LastSeenAt.After(data[j].LastSeenAt)
+	})
+
+	return
+}
+
+// Revoke ends a single session of the user
+func Revoke(ctx context.Context, store Store, userID int, sessionID string) error {
+	data, err := store.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if data.UserID != userID {
+		return ErrSessionNotFound
+	}
+
+	return store.Delete(ctx, sessionID)
+}
+
+// RevokeAll ends every session of the user except exceptID, which may be empty,
+// and returns the IDs of the revoked sessions
+func RevokeAll(ctx context.Context, store Store, userID int, exceptID string) (revoked []string, err error) {
+	all, err := store.ListByUser(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	for _, s := range all {
+		if s.SessionID == exceptID {
+			continue
+		}
+		if err = store.Delete(ctx, s.SessionID); err != nil {
+			return
+		}
+		revoked = append(revoked, s.SessionID)
+	}
+
+	return
+}
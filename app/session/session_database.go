@@ -0,0 +1,58 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"project-name/app/database"
+	"project-name/app/models"
+
+	"gorm.io/gorm"
+)
+
+type databaseStore struct {
+	db *gorm.DB
+}
+
+// NewDatabaseStore keeps sessions in the sessions table, joining the transaction
+// carried by the context when there is one
+func NewDatabaseStore(db *gorm.DB) Store {
+	return databaseStore{db: db}
+}
+
+func (s databaseStore) Save(ctx context.Context, data models.Session) error {
+	db := database.Conn(ctx, s.db)
+
+	var existing models.Session
+	err := db.Where("session_id = ?", data.SessionID).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return db.Create(&data).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	return db.Model(&existing).Updates(map[string]interface{}{
+		"ip_address":   data.IPAddress,
+		"last_seen_at": data.LastSeenAt,
+		"expires_at":   data.ExpiresAt,
+	}).Error
+}
+
+func (s databaseStore) Get(ctx context.Context, sessionID string) (data models.Session, err error) {
+	err = database.Conn(ctx, s.db).Where("session_id = ?", sessionID).First(&data).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = ErrSessionNotFound
+	}
+
+	return
+}
+
+func (s databaseStore) ListByUser(ctx context.Context, userID int) (data []models.Session, err error) {
+	err = database.Conn(ctx, s.db).Where("user_id = ?", userID).Order("last_seen_at DESC").Find(&data).Error
+
+	return
+}
+
+func (s databaseStore) Delete(ctx context.Context, sessionID string) error {
+	return database.Conn(ctx, s.db).Unscoped().Where("session_id = ?", sessionID).Delete(&models.Session{}).Error
+}
@@ -0,0 +1,111 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"project-name/app/models"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+type redisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore keeps every session under its own key expiring with it, and
+// indexes them per user in a set
+func NewRedisStore(client *redis.Client) Store {
+	return redisStore{client: client}
+}
+
+func sessionKey(sessionID string) string {
+	return "session:" + sessionID
+}
+
+func userSessionsKey(userID int) string {
+	return "user_sessions:" + strconv.Itoa(userID)
+}
+
+// extendTTL only ever pushes the expiry of KEYS[1] out to ARGV[1] milliseconds, the
+// user index must outlive the longest session in it, not the one saved last
+const extendTTL = `
+if redis.call("PTTL", KEYS[1]) < tonumber(ARGV[1]) then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return 0`
+
+func (s redisStore) Save(ctx context.Context, data models.Session) error {
+	ttl := time.Until(data.ExpiresAt)
+	if ttl <= 0 {
+		return s.Delete(ctx, data.SessionID)
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	pipe := s.client.WithContext(ctx).TxPipeline()
+	pipe.Set(sessionKey(data.SessionID), payload, ttl)
+	pipe.SAdd(userSessionsKey(data.UserID), data.SessionID)
+	pipe.Eval(extendTTL, []string{userSessionsKey(data.UserID)}, ttl.Milliseconds())
+	_, err = pipe.Exec()
+
+	return err
+}
+
+func (s redisStore) Get(ctx context.Context, sessionID string) (data models.Session, err error) {
+	payload, err := s.client.WithContext(ctx).Get(sessionKey(sessionID)).Bytes()
+	if err == redis.Nil {
+		err = ErrSessionNotFound
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(payload, &data)
+
+	return
+}
+
+func (s redisStore) ListByUser(ctx context.Context, userID int) (data []models.Session, err error) {
+	ids, err := s.client.WithContext(ctx).SMembers(userSessionsKey(userID)).Result()
+	if err != nil {
+		return
+	}
+
+	for _, id := range ids {
+		item, err := s.Get(ctx, id)
+		if err == ErrSessionNotFound {
+			// expired on its own, drop it from the index
+			s.client.WithContext(ctx).SRem(userSessionsKey(userID), id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, item)
+	}
+
+	return
+}
+
+func (s redisStore) Delete(ctx context.Context, sessionID string) error {
+	data, err := s.Get(ctx, sessionID)
+	if err == ErrSessionNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	pipe := s.client.WithContext(ctx).TxPipeline()
+	pipe.Del(sessionKey(sessionID))
+	pipe.SRem(userSessionsKey(data.UserID), sessionID)
+	_, err = pipe.Exec()
+
+	return err
+}
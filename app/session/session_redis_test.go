@@ -0,0 +1,82 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"project-name/app/models"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// testRedis connects to TEST_REDIS_ADDR and removes the keys of userID when the
+// test ends, the test is skipped when no server is configured
+func testRedis(t *testing.T, userID int) *redis.Client {
+	t.Helper()
+
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		t.Skip("TEST_REDIS_ADDR is not set")
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: addr, Password: os.Getenv("TEST_REDIS_PASSWORD")})
+	if err := client.Ping().Err(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		ids, _ := client.SMembers(userSessionsKey(userID)).Result()
+		for _, id := range ids {
+			client.Del(sessionKey(id))
+		}
+		client.Del(userSessionsKey(userID))
+		client.Close()
+	})
+
+	return client
+}
+
+func TestRedisStoreIndexTTL(t *testing.T) {
+	userID := int(time.Now().UnixNano() % 1_000_000_000)
+	client := testRedis(t, userID)
+	store := NewRedisStore(client)
+	ctx := context.Background()
+
+	long := testSession(fmt.Sprintf("test-long-%d", userID), userID, 0, time.Hour)
+	short := testSession(fmt.Sprintf("test-short-%d", userID), userID, 0, time.Minute)
+	for _, data := range []models.Session{long, short} {
+		if err := store.Save(ctx, data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the short session saved last must not cut the index down to its own TTL
+	ttl, err := client.PTTL(userSessionsKey(userID)).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ttl < 59*time.Minute {
+		t.Errorf("index ttl = %v, want at least the longest session", ttl)
+	}
+
+	if err := store.Delete(ctx, short.SessionID); err != nil {
+		t.Fatal(err)
+	}
+	if member, _ := client.SIsMember(userSessionsKey(userID), short.SessionID).Result(); member {
+		t.Error("deleted session still indexed")
+	}
+
+	// a session that expired on its own is dropped from the index on listing
+	client.Del(sessionKey(long.SessionID))
+	sessions, err := store.ListByUser(ctx, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 0 {
+		t.Errorf("sessions = %v", sessionIDs(sessions))
+	}
+	if count, _ := client.SCard(userSessionsKey(userID)).Result(); count != 0 {
+		t.Errorf("index keeps %d expired sessions", count)
+	}
+}
@@ -0,0 +1,206 @@
+package session
+
+import (
+	"context"
+	"project-name/app/models"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+// memStore keeps sessions in memory, expired ones included like a database
+type memStore struct {
+	sessions map[string]models.Session
+}
+
+func newMemStore(sessions ...models.Session) *memStore {
+	s := &memStore{sessions: map[string]models.Session{}}
+	for _, data := range sessions {
+		s.sessions[data.SessionID] = data
+	}
+	return s
+}
+
+func (s *memStore) Save(ctx context.Context, data models.Session) error {
+	s.sessions[data.SessionID] = data
+	return nil
+}
+
+func (s *memStore) Get(ctx context.Context, sessionID string) (models.Session, error) {
+	data, ok := s.sessions[sessionID]
+	if !ok {
+		return models.Session{}, ErrSessionNotFound
+	}
+	return data, nil
+}
+
+func (s *memStore) ListByUser(ctx context.Context, userID int) (data []models.Session, err error) {
+	for _, item := range s.sessions {
+		if item.UserID == userID {
+			data = append(data, item)
+		}
+	}
+	return
+}
+
+func (s *memStore) Delete(ctx context.Context, sessionID string) error {
+	delete(s.sessions, sessionID)
+	return nil
+}
+
+func (s *memStore) ids() (ids []string) {
+	for id := range s.sessions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return
+}
+
+func testSession(id string, userID int, lastSeen, expires time.Duration) models.Session {
+	now := time.Now()
+	return models.Session{SessionID: id, UserID: userID, IPAddress: "192.0.2.1", LastSeenAt: now.Add(lastSeen), ExpiresAt: now.Add(expires)}
+}
+
+func sessionIDs(sessions []models.Session) (ids []string) {
+	for _, data := range sessions {
+		ids = append(ids, data.SessionID)
+	}
+	return
+}
+
+func TestListActive(t *testing.T) {
+	store := newMemStore(
+		testSession("old", 1, -time.Hour, time.Hour),
+		testSession("recent", 1, -time.Minute, time.Hour),
+		testSession("expired", 1, -2*time.Hour, -time.Minute),
+		testSession("other", 2, 0, time.Hour),
+	)
+
+	got, err := ListActive(context.Background(), store, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := sessionIDs(got); !reflect.DeepEqual(ids, []string{"recent", "old"}) {
+		t.Errorf("sessions = %v, want the active ones most recent first", ids)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore(testSession("mine", 1, 0, time.Hour), testSession("theirs", 2, 0, time.Hour))
+
+	if err := Revoke(ctx, store, 1, "theirs"); err != ErrSessionNotFound {
+		t.Errorf("session of another user: err = %v", err)
+	}
+	if err := Revoke(ctx, store, 1, "unknown"); err != ErrSessionNotFound {
+		t.Errorf("unknown session: err = %v", err)
+	}
+	if err := Revoke(ctx, store, 1, "mine"); err != nil {
+		t.Fatal(err)
+	}
+
+	if ids := store.ids(); !reflect.DeepEqual(ids, []string{"theirs"}) {
+		t.Errorf("left = %v", ids)
+	}
+}
+
+func TestRevokeAll(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		except  string
+		revoked []string
+		left    []string
+	}{
+		{"keep the current session", "b", []string{"a", "c"}, []string{"b", "other"}},
+		{"everything", "", []string{"a", "b", "c"}, []string{"other"}},
+		{"except a session of another user", "other", []string{"a", "b", "c"}, []string{"other"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemStore(
+				testSession("a", 1, 0, time.Hour),
+				testSession("b", 1, 0, time.Hour),
+				testSession("c", 1, 0, -time.Minute),
+				testSession("other", 2, 0, time.Hour),
+			)
+
+			revoked, err := RevokeAll(ctx, store, 1, tt.except)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sort.Strings(revoked)
+			if !reflect.DeepEqual(revoked, tt.revoked) {
+				t.Errorf("revoked = %v, want %v", revoked, tt.revoked)
+			}
+			if left := store.ids(); !reflect.DeepEqual(left, tt.left) {
+				t.Errorf("left = %v, want %v", left, tt.left)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		session models.Session
+		userID  int
+		ip      string
+		err     error
+		touched bool
+	}{
+		{"active", testSession("s", 1, -time.Second, time.Hour), 1, "192.0.2.1", nil, false},
+		{"idle past the touch interval", testSession("s", 1, -2*touchInterval, time.Hour), 1, "192.0.2.1", nil, true},
+		{"new address", testSession("s", 1, -time.Second, time.Hour), 1, "198.51.100.7", nil, true},
+		{"another user", testSession("s", 1, -time.Second, time.Hour), 2, "192.0.2.1", ErrSessionNotFound, false},
+		{"expired", testSession("s", 1, -time.Hour, -time.Second), 1, "192.0.2.1", ErrSessionNotFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemStore(tt.session)
+
+			_, err := Validate(ctx, store, "s", tt.userID, tt.ip)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+
+			saved := store.sessions["s"]
+			if touched := !saved.LastSeenAt.Equal(tt.session.LastSeenAt); touched != tt.touched {
+				t.Errorf("touched = %v, want %v", touched, tt.touched)
+			}
+			if tt.touched && saved.IPAddress != tt.ip {
+				t.Errorf("ip = %s, want %s", saved.IPAddress, tt.ip)
+			}
+		})
+	}
+
+	if _, err := Validate(ctx, newMemStore(), "gone", 1, "192.0.2.1"); err != ErrSessionNotFound {
+		t.Errorf("unknown session: err = %v", err)
+	}
+}
+
+func TestCreateAndExtend(t *testing.T) {
+	t.Setenv("JWT_REFRESH_TOKEN_TTL", "60")
+	ctx := context.Background()
+	store := newMemStore()
+
+	data, err := Create(ctx, store, 1, "", "go test", "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.SessionID == "" || data.Device != "go test" || time.Until(data.ExpiresAt) < 59*time.Minute {
+		t.Errorf("created = %+v", data)
+	}
+
+	store.sessions[data.SessionID] = testSession(data.SessionID, 1, -time.Hour, time.Minute)
+	if err := Extend(ctx, store, data.SessionID); err != nil {
+		t.Fatal(err)
+	}
+	if extended := store.sessions[data.SessionID]; time.Until(extended.ExpiresAt) < 59*time.Minute || time.Since(extended.LastSeenAt) > time.Minute {
+		t.Errorf("extended = %+v", extended)
+	}
+}
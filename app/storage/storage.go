@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"project-name/config"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound   = errors.New("object not found")
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+// Backend stores uploaded files by key. Keys are slash separated relative paths.
+type Backend interface {
+	// Put stores r under key, replacing any object there. size is -1 when unknown.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the object, the caller closes it
+	Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error)
+	// Delete removes the object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// SignedURL returns a URL that gives read access to the object until ttl has passed
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// Default is the backend selected by STORAGE_DRIVER
+var Default Backend
+
+// Init sets Default from the configuration
+func Init() {
+	cfg := config.LoadConfig()
+
+	switch cfg.StorageDriver {
+	case "S3":
+		Default = NewS3(S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PathStyle: cfg.S3ForcePathStyle,
+		})
+	case "LOCAL":
+		// the router serves the signed URLs under /storage, DirPath itself is not public
+		Default = NewLocal(cfg.DirPath, strings.TrimSuffix(cfg.BaseUrl, "/")+"/storage", []byte("storage:"+cfg.AppKey))
+	default:
+		log.Panicf("Unknown STORAGE_DRIVER %q", cfg.StorageDriver)
+	}
+
+	log.Println("Storage:", cfg.StorageDriver)
+}
+
+// URL is the signed URL of a stored file for API responses, empty when there is
+// no file
+func URL(key string) string {
+	if key == "" || Default == nil {
+		return ""
+	}
+
+	url, err := Default.SignedURL(context.Background(), key, time.Duration(config.LoadConfig().StorageURLTTL)*time.Second)
+	if err != nil {
+		return ""
+	}
+
+	return url
+}
+
+// Open returns the object as a ReadSeeker, as http.ServeContent needs for Range
+// requests. Objects of backends that cannot seek are fetched by byte range on demand.
+func Open(ctx context.Context, backend Backend, key string) (io.ReadSeekCloser, ObjectInfo, error) {
+	if ranged, ok := backend.(rangeGetter); ok {
+		info, err := backend.Stat(ctx, key)
+		if err != nil {
+			return nil, info, err
+		}
+
+		return &rangeReader{ctx: ctx, backend: ranged, key: key, size: info.Size}, info, nil
+	}
+
+	body, info, err := backend.Get(ctx, key)
+	if err != nil {
+		return nil, info, err
+	}
+	if seeker, ok := body.(io.ReadSeekCloser); ok {
+		return seeker, info, nil
+	}
+	body.Close()
+
+	return nil, info, errors.New("storage backend cannot seek")
+}
+
+// rangeGetter reads an object from offset to its end
+type rangeGetter interface {
+	GetFrom(ctx context.Context, key string, offset int64) (io.ReadCloser, error)
+}
+
+// rangeReader only opens the object on the first Read after a Seek
+type rangeReader struct {
+	ctx     context.Context
+	backend rangeGetter
+	key     string
+	size    int64
+	pos     int64
+	body    io.ReadCloser
+}
+
+func (r *rangeReader) Read(p []byte) (int, error) {
+	if r.pos >= r.size {
+		return 0, io.EOF
+	}
+	if r.body == nil {
+		body, err := r.backend.GetFrom(r.ctx, r.key, r.pos)
+		if err != nil {
+			return 0, err
+		}
+		r.body = body
+	}
+
+	n, err := r.body.Read(p)
+	r.pos += int64(n)
+
+	return n, err
+}
+
+func (r *rangeReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.pos
+	case io.SeekEnd:
+		offset += r.size
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if offset != r.pos {
+		r.Close()
+		r.pos = offset
+	}
+
+	return offset, nil
+}
+
+func (r *rangeReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+
+	err := r.body.Close()
+	r.body = nil
+
+	return err
+}
+
+// cleanKey rejects keys that are empty, absolute or climb out of the storage root
+func cleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return "", ErrInvalidKey
+		}
+	}
+
+	return key, nil
+}
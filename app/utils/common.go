@@ -1,6 +1,10 @@
 package utils
 
 import (
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -17,6 +21,7 @@ import (
 	"github.com/hablullah/go-hijri"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
+	"gorm.io/gorm"
 )
 
 type Response struct {
@@ -149,12 +154,12 @@ func StripTags(str string) string {
 	return str
 }
 
-func LastId(table string) (id int) {
+func LastId(db *gorm.DB, table string) (id int) {
 	type OnlyId struct {
 		ID int
 	}
 	var last OnlyId
-	config.DB.Table(table).Order("id desc").Limit(1).Scan(&last)
+	db.Table(table).Order("id desc").Limit(1).Scan(&last)
 
 	id = last.ID + 1
 	return
@@ -193,7 +198,7 @@ func StripTagsFromStruct(input interface{}) {
 	}
 }
 
-func GenerateInvoiceID(initial string, model interface{}) (invoiceID string) {
+func GenerateInvoiceID(db *gorm.DB, initial string, model interface{}) (invoiceID string) {
 	location, err := time.LoadLocation("Asia/Jakarta")
 	if err != nil {
 		location = time.Local
@@ -201,7 +206,7 @@ func GenerateInvoiceID(initial string, model interface{}) (invoiceID string) {
 		// fmt.Println("Failed to get Asia/Jakarta time when generating invoice id")
 	}
 	var count int64
-	config.DB.Model(&model).Count(&count)
+	db.Model(model).Count(&count)
 	if count == 0 {
 		count = 1
 	}
@@ -258,12 +263,12 @@ func ConvertToGregorianDate(date time.Time) (GregorianDate time.Time, err error)
 	return
 }
 
-func ExecuteSQL(filename string, clean bool) (err error) {
+func ExecuteSQL(db *gorm.DB, filename string, clean bool) (err error) {
 	sqlFile, err := os.ReadFile(filename)
 	if err != nil {
 		return
 	}
-	if err = config.DB.Exec(string(sqlFile)).Error; err != nil {
+	if err = db.Exec(string(sqlFile)).Error; err != nil {
 		return
 	}
 
@@ -330,3 +335,18 @@ func GetDesktopGeoTagging() (ip, latitude, longitude string) {
 
 	return
 }
+
+// GenerateSecureToken returns a url-safe random token built from n bytes of crypto/rand
+func GenerateSecureToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := cryptorand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the hex encoded SHA-256 of a token, used to store secrets that are only ever compared
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"project-name/config"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errInvalidCursor = errors.New("invalid cursor")
+	errExpiredCursor = errors.New("cursor has expired, start again from the first page")
+)
+
+// cursorTTL bounds how long a page position can be replayed
+const cursorTTL = 24 * time.Hour
+
+// pageCursor marks the row a keyset page starts after. Sort and Order are kept so
+// a cursor cannot be replayed against a different ordering.
+type pageCursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Kind  string `json:"k"`
+	Value string `json:"v"`
+	ID    uint   `json:"i"`
+	// Back pages towards the start of the list
+	Back bool `json:"b,omitempty"`
+	// Expires is the Unix time after which the cursor is refused
+	Expires int64 `json:"e"`
+}
+
+// encodeCursor serializes c as base64url(json).base64url(hmac) so clients cannot
+// forge a position, valid for cursorTTL
+func encodeCursor(c pageCursor) (string, error) {
+	c.Expires = time.Now().Add(cursorTTL).Unix()
+
+	return signCursor(c)
+}
+
+func signCursor(c pageCursor) (string, error) {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	body := base64.RawURLEncoding.EncodeToString(payload)
+
+	return body + "." + base64.RawURLEncoding.EncodeToString(cursorSignature(body)), nil
+}
+
+func decodeCursor(s string) (c pageCursor, err error) {
+	body, sig, ok := strings.Cut(s, ".")
+	if !ok {
+		err = errInvalidCursor
+		return
+	}
+
+	expected, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(expected, cursorSignature(body)) {
+		err = errInvalidCursor
+		return
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		err = errInvalidCursor
+		return
+	}
+	if err = json.Unmarshal(payload, &c); err != nil {
+		err = errInvalidCursor
+		return
+	}
+	if time.Now().Unix() > c.Expires {
+		err = errExpiredCursor
+	}
+
+	return
+}
+
+func cursorSignature(body string) []byte {
+	mac := hmac.New(sha256.New, []byte("cursor:"+config.LoadConfig().AppKey))
+	mac.Write([]byte(body))
+
+	return mac.Sum(nil)
+}
+
+// cursorValue stores a sort column value as text with its kind so it is bound
+// with the right type when the cursor comes back
+func cursorValue(value interface{}) (kind, text string) {
+	switch v := value.(type) {
+	case time.Time:
+		return "t", v.Format(time.RFC3339Nano)
+	case *time.Time:
+		if v != nil {
+			return "t", v.Format(time.RFC3339Nano)
+		}
+	case string:
+		return "s", v
+	case bool:
+		return "b", strconv.FormatBool(v)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return "i", fmt.Sprint(v)
+	case float32, float64:
+		return "f", fmt.Sprint(v)
+	}
+
+	return "", ""
+}
+
+func (c pageCursor) value() (interface{}, error) {
+	switch c.Kind {
+	case "t":
+		return time.Parse(time.RFC3339Nano, c.Value)
+	case "s":
+		return c.Value, nil
+	case "b":
+		return strconv.ParseBool(c.Value)
+	case "i":
+		return strconv.ParseInt(c.Value, 10, 64)
+	case "f":
+		return strconv.ParseFloat(c.Value, 64)
+	}
+
+	return nil, errInvalidCursor
+}
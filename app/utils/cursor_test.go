@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"encoding/base64"
+	"project-name/app/reqres"
+	"strings"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	t.Setenv("APP_KEY", "cursor-test")
+
+	for _, value := range []interface{}{
+		time.Date(2026, 10, 18, 8, 30, 0, 123456789, time.UTC),
+		"budi",
+		true,
+		uint(42),
+		1.5,
+	} {
+		c := pageCursor{Sort: "name", Order: "ASC", ID: 7, Back: true}
+		c.Kind, c.Value = cursorValue(value)
+
+		encoded, err := encodeCursor(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := decodeCursor(encoded)
+		if err != nil {
+			t.Fatalf("%v: %v", value, err)
+		}
+
+		if decoded.Expires < time.Now().Add(cursorTTL-time.Minute).Unix() {
+			t.Errorf("%v: expires = %d", value, decoded.Expires)
+		}
+		decoded.Expires = 0
+		if decoded != c {
+			t.Errorf("decoded = %+v, want %+v", decoded, c)
+		}
+		if _, err := decoded.value(); err != nil {
+			t.Errorf("%v: value: %v", value, err)
+		}
+	}
+}
+
+func TestCursorVerification(t *testing.T) {
+	t.Setenv("APP_KEY", "cursor-test")
+
+	valid, _ := encodeCursor(pageCursor{Sort: "id", Order: "DESC", Kind: "i", Value: "10", ID: 10})
+	body, sig, _ := strings.Cut(valid, ".")
+
+	// the client moves the position but keeps the signature
+	payload, _ := base64.RawURLEncoding.DecodeString(body)
+	moved := base64.RawURLEncoding.EncodeToString([]byte(strings.Replace(string(payload), `"i":10`, `"i":99999`, 1)))
+
+	mac, _ := base64.RawURLEncoding.DecodeString(sig)
+	mac[0] ^= 1
+	flipped := base64.RawURLEncoding.EncodeToString(mac)
+
+	expired, _ := signCursor(pageCursor{Sort: "id", Order: "DESC", Kind: "i", Value: "10", ID: 10, Expires: time.Now().Add(-time.Minute).Unix()})
+
+	t.Setenv("APP_KEY", "another-key")
+	foreign, _ := encodeCursor(pageCursor{Sort: "id", Order: "DESC", Kind: "i", Value: "10", ID: 10})
+	t.Setenv("APP_KEY", "cursor-test")
+
+	tests := []struct {
+		name   string
+		cursor string
+		err    error
+	}{
+		{"valid", valid, nil},
+		{"moved position", moved + "." + sig, errInvalidCursor},
+		{"flipped signature", body + "." + flipped, errInvalidCursor},
+		{"signature of another body", body + "." + strings.Split(foreign, ".")[1], errInvalidCursor},
+		{"signed with another key", foreign, errInvalidCursor},
+		{"unsigned", body, errInvalidCursor},
+		{"empty signature", body + ".", errInvalidCursor},
+		{"not base64", "!!!.???", errInvalidCursor},
+		{"expired", expired, errExpiredCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.cursor); err != tt.err {
+				t.Errorf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderValidateCursor(t *testing.T) {
+	t.Setenv("APP_KEY", "cursor-test")
+
+	issued, _ := encodeCursor(pageCursor{Sort: "name", Order: "ASC", Kind: "s", Value: "budi", ID: 3})
+	badKind, _ := encodeCursor(pageCursor{Sort: "name", Order: "ASC", Kind: "x", Value: "budi", ID: 3})
+	expired, _ := signCursor(pageCursor{Sort: "name", Order: "ASC", Kind: "s", Value: "budi", ID: 3, Expires: 1})
+
+	tests := []struct {
+		name  string
+		param reqres.ReqPaging
+		err   string
+	}{
+		{"same ordering", reqres.ReqPaging{CursorMode: true, Cursor: issued, Sort: "name", Order: "ASC"}, ""},
+		{"first page", reqres.ReqPaging{CursorMode: true, Sort: "name", Order: "ASC"}, ""},
+		{"offset mode ignores it", reqres.ReqPaging{Cursor: "garbage", Sort: "name", Order: "ASC"}, ""},
+		{"other direction", reqres.ReqPaging{CursorMode: true, Cursor: issued, Sort: "name", Order: "DESC"}, "cursor was issued for a different sort order"},
+		{"other column", reqres.ReqPaging{CursorMode: true, Cursor: issued, Sort: "id", Order: "ASC"}, "cursor was issued for a different sort order"},
+		{"tampered", reqres.ReqPaging{CursorMode: true, Cursor: issued + "x", Sort: "name", Order: "ASC"}, errInvalidCursor.Error()},
+		{"expired", reqres.ReqPaging{CursorMode: true, Cursor: expired, Sort: "name", Order: "ASC"}, errExpiredCursor.Error()},
+		{"unknown value kind", reqres.ReqPaging{CursorMode: true, Cursor: badKind, Sort: "name", Order: "ASC"}, errInvalidCursor.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testQuery.Validate(tt.param)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("rejected: %v", err)
+				}
+				return
+			}
+
+			errs, ok := err.(validation.Errors)
+			if !ok || errs["cursor"] == nil || errs["cursor"].Error() != tt.err {
+				t.Fatalf("err = %v, want cursor: %s", err, tt.err)
+			}
+		})
+	}
+}
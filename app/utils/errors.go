@@ -31,6 +31,12 @@ var (
 	ErrNoCookie              = errors.New("not found cookie header")
 	ErrUnprocessableEntity   = errors.New("unprocessable entity")
 	ErrAuthenticationFailed  = errors.New("authentication vailed")
+	ErrInvalidRefreshToken   = errors.New("invalid refresh token")
+	ErrExpiredRefreshToken   = errors.New("expired refresh token")
+	ErrRefreshTokenReused    = errors.New("refresh token reuse detected")
+	ErrInvalidResetToken     = errors.New("invalid or expired reset token")
+	ErrInvalidVerifyToken    = errors.New("invalid or expired verification token")
+	ErrTooManyRequests       = errors.New("too many requests")
 )
 
 // HttpErr interface
@@ -116,6 +122,15 @@ func NewForbiddenError(details interface{}) HttpErr {
 	}
 }
 
+// New Too Many Requests Error
+func NewTooManyRequestsError(details interface{}) HttpErr {
+	return HttpError{
+		ErrStatus:  http.StatusTooManyRequests,
+		ErrError:   ErrTooManyRequests.Error(),
+		ErrDetails: details,
+	}
+}
+
 // New Internal Server Error
 func NewInternalServerError(details interface{}) HttpErr {
 	log.Error(details.(error).Error())
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NormalizeName lowercases s and turns every run of characters other than letters
+// and digits into a single space
+func NormalizeName(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// MatchName reports whether query matches name and how well, lower scores being
+// better. Every word of the query has to start a word of the name, with one typo
+// allowed in words of 4 letters or more and two from 8 letters.
+func MatchName(query, name string) (score int, ok bool) {
+	query, name = NormalizeName(query), NormalizeName(name)
+	if query == "" {
+		return 0, false
+	}
+
+	switch {
+	case name == query:
+		return 0, true
+	case strings.HasPrefix(name, query):
+		return 1, true
+	}
+
+	nameWords := strings.Fields(name)
+	score = 2
+	for _, word := range strings.Fields(query) {
+		best := -1
+		for _, candidate := range nameWords {
+			if cost, ok := matchWord(word, candidate); ok && (best < 0 || cost < best) {
+				best = cost
+			}
+		}
+		if best < 0 {
+			return 0, false
+		}
+		score += best
+	}
+
+	return score, true
+}
+
+// matchWord matches a query word against the start of a name word. A prefix costs
+// nothing, typos cost 2 each so they rank after any prefix match.
+func matchWord(word, candidate string) (cost int, ok bool) {
+	if strings.HasPrefix(candidate, word) {
+		return 0, true
+	}
+
+	w, c := []rune(word), []rune(candidate)
+	allowed := 0
+	switch {
+	case len(w) >= 8:
+		allowed = 2
+	case len(w) >= 4:
+		allowed = 1
+	}
+	if allowed == 0 {
+		return 0, false
+	}
+
+	distance := levenshtein(w, c)
+	if len(c) > len(w) {
+		// the query may be a misspelled prefix of a longer word
+		if d := levenshtein(w, c[:len(w)]); d < distance {
+			distance = d
+		}
+	}
+	if distance > allowed {
+		return 0, false
+	}
+
+	return distance * 2, true
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -1,8 +1,11 @@
 package utils
 
 import (
-	"fmt"
+	"net/url"
 	"project-name/app/reqres"
+	"project-name/config"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -12,10 +15,16 @@ import (
 func PopulatePaging(c echo.Context, custom string) (param reqres.ReqPaging) {
 	customval := c.QueryParam(custom)
 	limit, _ := strconv.Atoi(c.QueryParam("limit"))
-	if limit == 0 {
+	if limit <= 0 {
 		limit = 10
 	}
+	if maxLimit := config.LoadConfig().PageMaxLimit; limit > maxLimit {
+		limit = maxLimit
+	}
 	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+	if offset < 0 {
+		offset = 0
+	}
 	page, _ := strconv.Atoi(c.QueryParam("page"))
 	if page == 0 && offset == 0 {
 		page = 1
@@ -34,18 +43,31 @@ func PopulatePaging(c echo.Context, custom string) (param reqres.ReqPaging) {
 	} else {
 		order = "DESC"
 	}
-	sort := c.QueryParam("order")
+	// the column is checked against the resource whitelist by QueryBuilder
+	sort := strings.TrimSpace(c.QueryParam("order"))
 	if sort == "" {
 		sort = "id"
 	}
+	// cursor= with an empty value asks for the first page in cursor mode
+	_, cursorMode := c.QueryParams()["cursor"]
+	count := !cursorMode
+	if value := c.QueryParam("count"); value != "" {
+		count, _ = strconv.ParseBool(value)
+	}
 	param = reqres.ReqPaging{
-		Search: c.QueryParam("search"),
-		Order:  order,
-		Limit:  limit,
-		Offset: offset,
-		Sort:   sort,
-		Custom: customval,
-		Page:   page}
+		Search:     c.QueryParam("search"),
+		Order:      order,
+		Limit:      limit,
+		Offset:     offset,
+		Sort:       sort,
+		Custom:     customval,
+		Page:       page,
+		Filters:    parseFilters(c.QueryParams()),
+		Draw:       draw,
+		CursorMode: cursorMode,
+		Cursor:     c.QueryParam("cursor"),
+		Count:      count,
+		Projection: PopulateProjection(c)}
 	return
 }
 
@@ -65,11 +87,14 @@ func PopulateResPaging(param *reqres.ReqPaging, data interface{}, totalResult in
 		back = true
 	}
 
-	fmt.Println("totalResult:", totalResult)
-	fmt.Println("totalFiltered:", totalFiltered)
+	draw := param.Draw
+	if draw == 0 {
+		draw = 1
+	}
+
 	output = reqres.ResPaging{
 		Status:          200,
-		Draw:            1,
+		Draw:            draw,
 		Data:            data,
 		Search:          param.Search,
 		Order:           param.Order,
@@ -85,3 +110,62 @@ func PopulateResPaging(param *reqres.ReqPaging, data interface{}, totalResult in
 	}
 	return
 }
+
+// PopulatePageResPaging builds the response of a list run by QueryBuilder.Find. When
+// the count was skipped recordsTotal and recordsFiltered are -1 and total_page is 0.
+func PopulatePageResPaging(param *reqres.ReqPaging, data interface{}, page Page) (output reqres.ResPaging) {
+	total, filtered := page.Total, page.Filtered
+	if !page.Counted {
+		total, filtered = 0, 0
+	}
+
+	// pages follow the filtered rows
+	output = PopulateResPaging(param, data, filtered, filtered)
+	output.TotalData = int(total)
+	output.Next = page.HasNext
+	output.Back = page.HasPrev
+	output.NextCursor = page.NextCursor
+	output.PrevCursor = page.PrevCursor
+
+	if !page.Counted {
+		output.TotalData = -1
+		output.RecordsFiltered = -1
+		output.TotalPage = 0
+	}
+	if param.CursorMode {
+		output.Offset = 0
+		output.CurrentPage = 0
+	}
+
+	return
+}
+
+var filterParam = regexp.MustCompile(`^filter\[([^\[\]]+)\](?:\[([^\[\]]+)\])?$`)
+
+// parseFilters reads filter[field]=value and filter[field][op]=value query params.
+// Fields and operators are only checked by the resource's QueryBuilder.
+func parseFilters(query url.Values) (filters []reqres.Filter) {
+	for key, values := range query {
+		match := filterParam.FindStringSubmatch(key)
+		if match == nil {
+			continue
+		}
+		for _, value := range values {
+			filters = append(filters, reqres.Filter{
+				Field:    match[1],
+				Operator: strings.ToLower(match[2]),
+				Value:    value,
+			})
+		}
+	}
+
+	// query params come from a map, keep the generated SQL stable
+	sort.SliceStable(filters, func(i, j int) bool {
+		if filters[i].Field != filters[j].Field {
+			return filters[i].Field < filters[j].Field
+		}
+		return filters[i].Operator < filters[j].Operator
+	})
+
+	return
+}
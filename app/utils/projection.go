@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"project-name/app/reqres"
+	"sort"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// Relation is a relation that can be loaded with expand=
+type Relation struct {
+	// Preload is the GORM association name
+	Preload string
+	// Columns the parent row needs for the relation to load, usually its foreign key
+	Columns []string
+}
+
+// Projection declares which response fields of a resource can be picked with
+// fields= and which relations can be loaded with expand=
+type Projection struct {
+	// Fields maps a response field to the columns it is built from
+	Fields map[string][]string
+	// Relations maps an expand name to the relation it loads
+	Relations map[string]Relation
+	// Always lists columns selected whatever fields are asked for, e.g. the primary key
+	Always []string
+}
+
+// PopulateProjection reads the comma separated fields and expand query params
+func PopulateProjection(c echo.Context) reqres.ReqProjection {
+	return reqres.ReqProjection{
+		Fields: splitList(c.QueryParam("fields")),
+		Expand: splitList(c.QueryParam("expand")),
+	}
+}
+
+// Validate checks the requested fields and relations against the declaration
+func (p Projection) Validate(req reqres.ReqProjection) error {
+	errs := validation.Errors{}
+
+	if unknown := unknownNames(req.Fields, func(name string) bool { _, ok := p.Fields[name]; return ok }); len(unknown) > 0 {
+		errs["fields"] = fmt.Errorf("unknown fields: %s", strings.Join(unknown, ", "))
+	}
+	if unknown := unknownNames(req.Expand, func(name string) bool { _, ok := p.Relations[name]; return ok }); len(unknown) > 0 {
+		errs["expand"] = fmt.Errorf("unknown relations: %s", strings.Join(unknown, ", "))
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Scope selects only the columns behind the requested fields, plus extra, and
+// preloads the expanded relations. Without fields every column is selected.
+func (p Projection) Scope(req reqres.ReqProjection, extra ...string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(req.Fields) > 0 {
+			seen := map[string]bool{}
+			var columns []string
+			add := func(names []string) {
+				for _, name := range names {
+					if !seen[name] {
+						seen[name] = true
+						columns = append(columns, name)
+					}
+				}
+			}
+
+			add(p.Always)
+			add(extra)
+			for _, field := range req.Fields {
+				add(p.Fields[field])
+			}
+			for _, name := range req.Expand {
+				add(p.Relations[name].Columns)
+			}
+
+			db = db.Select(columns)
+		}
+
+		for _, name := range req.Expand {
+			if relation, ok := p.Relations[name]; ok {
+				db = db.Preload(relation.Preload)
+			}
+		}
+
+		return db
+	}
+}
+
+// Project trims a response, or a slice of responses, down to the requested fields
+// and expanded relations. Without fields the response is returned as it is.
+func (p Projection) Project(req reqres.ReqProjection, response interface{}) (interface{}, error) {
+	if len(req.Fields) == 0 {
+		return response, nil
+	}
+
+	keep := map[string]bool{}
+	for _, name := range append(append([]string{}, req.Fields...), req.Expand...) {
+		keep[name] = true
+	}
+
+	raw, err := json.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+
+	pick := func(item map[string]interface{}) map[string]interface{} {
+		for key := range item {
+			if !keep[key] {
+				delete(item, key)
+			}
+		}
+		return item
+	}
+
+	// numbers stay json.Number so IDs are written back exactly
+	decode := func(v interface{}) error {
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		return decoder.Decode(v)
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("[")) {
+		var items []map[string]interface{}
+		if err := decode(&items); err != nil {
+			return nil, err
+		}
+		for i := range items {
+			items[i] = pick(items[i])
+		}
+		return items, nil
+	}
+
+	var item map[string]interface{}
+	if err := decode(&item); err != nil {
+		return nil, err
+	}
+
+	return pick(item), nil
+}
+
+func splitList(s string) (out []string) {
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+
+	return
+}
+
+func unknownNames(names []string, known func(string) bool) (unknown []string) {
+	for _, name := range names {
+		if !known(name) {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+
+	return
+}
@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"project-name/app/reqres"
+	"reflect"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+)
+
+type testRole struct {
+	ID   uint
+	Name string
+}
+
+type testUser struct {
+	ID     uint      `json:"id"`
+	Name   string    `json:"name"`
+	Email  string    `json:"email"`
+	RoleID int       `json:"role_id"`
+	Role   *testRole `json:"role,omitempty"`
+}
+
+var testProjection = Projection{
+	Fields: map[string][]string{
+		"id":        {"id"},
+		"name":      {"name"},
+		"email":     {"email"},
+		"role_id":   {"role_id"},
+		"full_name": {"name"},
+	},
+	Relations: map[string]Relation{
+		"role": {Preload: "Role", Columns: []string{"role_id"}},
+	},
+	Always: []string{"id"},
+}
+
+func TestPopulateProjection(t *testing.T) {
+	c := echo.New().NewContext(httptest.NewRequest("GET", "/?fields=name,+email,,&expand=role", nil), httptest.NewRecorder())
+
+	got := PopulateProjection(c)
+	want := reqres.ReqProjection{Fields: []string{"name", "email"}, Expand: []string{"role"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestProjectionValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  reqres.ReqProjection
+		errs map[string]string
+	}{
+		{"everything", reqres.ReqProjection{}, nil},
+		{"known fields and relations", reqres.ReqProjection{Fields: []string{"name", "full_name"}, Expand: []string{"role"}}, nil},
+		{"unknown field", reqres.ReqProjection{Fields: []string{"name", "password"}}, map[string]string{"fields": "unknown fields: password"}},
+		{"column that is not a field", reqres.ReqProjection{Fields: []string{"deleted_at", "Name"}}, map[string]string{"fields": "unknown fields: Name, deleted_at"}},
+		{"relation not whitelisted", reqres.ReqProjection{Expand: []string{"role", "sessions"}}, map[string]string{"expand": "unknown relations: sessions"}},
+		{"nested relation", reqres.ReqProjection{Expand: []string{"role.Permissions"}}, map[string]string{"expand": "unknown relations: role.Permissions"}},
+		{"preload name instead of expand name", reqres.ReqProjection{Expand: []string{"Role"}}, map[string]string{"expand": "unknown relations: Role"}},
+		{"both", reqres.ReqProjection{Fields: []string{"x"}, Expand: []string{"y"}}, map[string]string{"fields": "unknown fields: x", "expand": "unknown relations: y"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testProjection.Validate(tt.req)
+			if tt.errs == nil {
+				if err != nil {
+					t.Fatalf("rejected: %v", err)
+				}
+				return
+			}
+
+			errs, ok := err.(validation.Errors)
+			if !ok || len(errs) != len(tt.errs) {
+				t.Fatalf("err = %v, want %v", err, tt.errs)
+			}
+			for key, want := range tt.errs {
+				if errs[key] == nil || errs[key].Error() != want {
+					t.Errorf("%s = %v, want %s", key, errs[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestProjectionScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      reqres.ReqProjection
+		extra    []string
+		sql      string
+		preloads []string
+	}{
+		{"everything", reqres.ReqProjection{}, nil, `SELECT * FROM "test_users"`, nil},
+		{"fields", reqres.ReqProjection{Fields: []string{"email", "full_name", "name"}}, nil, `SELECT "id","email","name" FROM "test_users"`, nil},
+		{"sort column for the cursor", reqres.ReqProjection{Fields: []string{"email"}}, []string{"name"}, `SELECT "id","name","email" FROM "test_users"`, nil},
+		{"expand keeps the foreign key", reqres.ReqProjection{Fields: []string{"name"}, Expand: []string{"role"}}, nil, `SELECT "id","name","role_id" FROM "test_users"`, []string{"Role"}},
+		{"expand alone", reqres.ReqProjection{Expand: []string{"role"}}, nil, `SELECT * FROM "test_users"`, []string{"Role"}},
+		{"unknown names are ignored", reqres.ReqProjection{Fields: []string{"name", "password"}, Expand: []string{"sessions"}}, nil, `SELECT "id","name" FROM "test_users"`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt := dryRun(t).Model(&testUser{}).Scopes(testProjection.Scope(tt.req, tt.extra...)).Find(&[]testUser{}).Statement
+			if sql := stmt.SQL.String(); sql != tt.sql {
+				t.Errorf("sql = %s\nwant  %s", sql, tt.sql)
+			}
+
+			var preloads []string
+			for name := range stmt.Preloads {
+				preloads = append(preloads, name)
+			}
+			if !reflect.DeepEqual(preloads, tt.preloads) {
+				t.Errorf("preloads = %v, want %v", preloads, tt.preloads)
+			}
+		})
+	}
+}
+
+func TestProjectionProject(t *testing.T) {
+	users := []testUser{
+		{ID: 9007199254740993, Name: "Budi", Email: "budi@example.com", RoleID: 2, Role: &testRole{ID: 2, Name: "Admin"}},
+		{ID: 2, Name: "Siti", Email: "siti@example.com", RoleID: 3},
+	}
+
+	tests := []struct {
+		name     string
+		req      reqres.ReqProjection
+		response interface{}
+		want     string
+	}{
+		{"everything", reqres.ReqProjection{}, users[1], `{"id":2,"name":"Siti","email":"siti@example.com","role_id":3}`},
+		{"one", reqres.ReqProjection{Fields: []string{"name"}}, users[1], `{"name":"Siti"}`},
+		{"list with an expanded relation", reqres.ReqProjection{Fields: []string{"id", "name"}, Expand: []string{"role"}}, users, `[{"id":9007199254740993,"name":"Budi","role":{"ID":2,"Name":"Admin"}},{"id":2,"name":"Siti"}]`},
+		{"empty list", reqres.ReqProjection{Fields: []string{"name"}}, []testUser{}, `[]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projected, err := testProjection.Project(tt.req, tt.response)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, _ := json.Marshal(projected)
+			if string(got) != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,483 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"project-name/app/reqres"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Filter operators accepted as filter[field][op]. A filter without operator is eq.
+const (
+	FilterEq      = "eq"
+	FilterNe      = "ne"
+	FilterLt      = "lt"
+	FilterLte     = "lte"
+	FilterGt      = "gt"
+	FilterGte     = "gte"
+	FilterIn      = "in"
+	FilterLike    = "like"
+	FilterBetween = "between"
+	FilterIsNull  = "isnull"
+)
+
+// FieldType decides how filter values are parsed and which operators apply
+type FieldType int
+
+const (
+	FieldString FieldType = iota
+	FieldInt
+	FieldBool
+	FieldTime
+)
+
+// FilterField is a column that can be filtered and the type of its values
+type FilterField struct {
+	Column string
+	Type   FieldType
+}
+
+var filterOperators = map[FieldType][]string{
+	FieldString: {FilterEq, FilterNe, FilterIn, FilterLike, FilterIsNull},
+	FieldInt:    {FilterEq, FilterNe, FilterLt, FilterLte, FilterGt, FilterGte, FilterIn, FilterBetween, FilterIsNull},
+	FieldBool:   {FilterEq, FilterNe, FilterIsNull},
+	FieldTime:   {FilterEq, FilterNe, FilterLt, FilterLte, FilterGt, FilterGte, FilterBetween, FilterIsNull},
+}
+
+var filterTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+// QueryBuilder turns paging params into GORM scopes for one resource. Only the
+// columns listed here can reach SQL, as quoted identifiers; every value is bound
+// as a parameter.
+type QueryBuilder struct {
+	// Searchable columns are matched with ILIKE against the search param
+	Searchable []string
+	// Sortable maps the name accepted in the query string to the column
+	Sortable map[string]string
+	// Filterable maps the name used in filter[name] to its column and type
+	Filterable map[string]FilterField
+	// DefaultSort is the Sortable name used when none is given
+	DefaultSort string
+}
+
+// Validate checks the sort column and every filter of param against the
+// whitelist. The returned validation.Errors is keyed by query param.
+func (q QueryBuilder) Validate(param reqres.ReqPaging) error {
+	errs := validation.Errors{}
+
+	if param.Sort != "" {
+		if _, ok := q.Sortable[param.Sort]; !ok {
+			errs["order"] = fmt.Errorf("cannot sort by %q", param.Sort)
+		}
+	}
+
+	for _, filter := range param.Filters {
+		if _, err := q.compileFilter(filter); err != nil {
+			errs[filterParamName(filter)] = err
+		}
+	}
+
+	if param.CursorMode && param.Cursor != "" {
+		if _, _, err := q.decodeCursor(param); err != nil {
+			errs["cursor"] = err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Where scopes the query to the search term and filters of param. Filters that
+// do not compile are skipped, call Validate first to report them.
+func (q QueryBuilder) Where(param reqres.ReqPaging) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if param.Search != "" && len(q.Searchable) > 0 {
+			pattern := "%" + EscapeLike(param.Search) + "%"
+			exprs := make([]clause.Expression, 0, len(q.Searchable))
+			for _, column := range q.Searchable {
+				exprs = append(exprs, clause.Expr{SQL: "? ILIKE ?", Vars: []interface{}{clause.Column{Name: column}, pattern}})
+			}
+			db = db.Where(clause.Or(exprs...))
+		}
+
+		for _, filter := range param.Filters {
+			expr, err := q.compileFilter(filter)
+			if err != nil {
+				continue
+			}
+			db = db.Where(expr)
+		}
+
+		return db
+	}
+}
+
+// OrderBy sorts by the whitelisted column of param, falling back to DefaultSort.
+// Ties are broken by id so pages are stable.
+func (q QueryBuilder) OrderBy(param reqres.ReqPaging) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		_, column := q.sortColumn(param)
+		if column == "" {
+			return db
+		}
+
+		return orderByColumn(db, column, param.Order == "DESC")
+	}
+}
+
+// Page describes a list query run by Find beyond its rows
+type Page struct {
+	// Total is the number of rows before search and filters, Filtered the number
+	// matching them. Both are only set when Counted.
+	Total      int64
+	Filtered   int64
+	Counted    bool
+	HasNext    bool
+	HasPrev    bool
+	NextCursor string
+	PrevCursor string
+}
+
+// Find runs the list query of param on db and fills out, a pointer to a slice of
+// models. It pages by offset or, in cursor mode, by keyset on (sort column, id),
+// and only counts the total when param.Count is set. scopes apply to the row
+// query alone, e.g. preloads that would break the count.
+func (q QueryBuilder) Find(db *gorm.DB, param reqres.ReqPaging, out interface{}, scopes ...func(*gorm.DB) *gorm.DB) (page Page, err error) {
+	// the conditions of the caller stay in both counts, search and filters only
+	// in the filtered one
+	db = db.Session(&gorm.Session{})
+	base := db
+	db = db.Scopes(q.Where(param))
+
+	if param.Count {
+		if err = base.Count(&page.Total).Error; err != nil {
+			return
+		}
+		page.Filtered = page.Total
+		if param.Search != "" || len(param.Filters) > 0 {
+			if err = db.Session(&gorm.Session{}).Count(&page.Filtered).Error; err != nil {
+				return
+			}
+		}
+		page.Counted = true
+	}
+
+	if !param.CursorMode {
+		// one extra row tells whether there is a next page without counting
+		tx := db.Session(&gorm.Session{}).Scopes(q.OrderBy(param)).Scopes(scopes...).
+			Offset(param.Offset).Limit(param.Limit + 1).Find(out)
+		if err = tx.Error; err != nil {
+			return
+		}
+
+		page.HasNext = trimRows(out, param.Limit)
+		page.HasPrev = param.Offset > 0
+
+		return
+	}
+
+	sortName, column := q.sortColumn(param)
+	desc := param.Order == "DESC"
+
+	var after pageCursor
+	hasCursor := param.Cursor != ""
+	if hasCursor {
+		var value interface{}
+		if after, value, err = q.decodeCursor(param); err != nil {
+			return
+		}
+
+		// walking backwards flips both the comparison and the order
+		less := desc != after.Back
+		op := ">"
+		if less {
+			op = "<"
+		}
+		db = db.Where(clause.Expr{
+			SQL:  "(?, ?) " + op + " (?, ?)",
+			Vars: []interface{}{clause.Column{Name: column}, clause.Column{Name: "id"}, value, after.ID},
+		})
+	}
+
+	tx := orderByColumn(db.Session(&gorm.Session{}), column, desc != after.Back).Scopes(scopes...).
+		Limit(param.Limit + 1).Find(out)
+	if err = tx.Error; err != nil {
+		return
+	}
+
+	more := trimRows(out, param.Limit)
+	if after.Back {
+		reverseRows(out)
+	}
+
+	first, last, err := cursorRows(tx, out, column)
+	if err != nil {
+		return
+	}
+	if first == nil {
+		// rows were removed since the cursor was issued, let the client turn around
+		if hasCursor {
+			turn := after
+			turn.Back = !after.Back
+			var c string
+			if c, err = encodeCursor(turn); err != nil {
+				return
+			}
+			if turn.Back {
+				page.HasPrev, page.PrevCursor = true, c
+			} else {
+				page.HasNext, page.NextCursor = true, c
+			}
+		}
+		return
+	}
+
+	page.HasNext = more || (hasCursor && after.Back)
+	page.HasPrev = (more && after.Back) || (hasCursor && !after.Back)
+
+	if page.HasNext {
+		last.Sort, last.Order = sortName, param.Order
+		if page.NextCursor, err = encodeCursor(*last); err != nil {
+			return
+		}
+	}
+	if page.HasPrev {
+		first.Sort, first.Order, first.Back = sortName, param.Order, true
+		if page.PrevCursor, err = encodeCursor(*first); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// SortColumn is the column param sorts by, a projection must keep it selected for cursor paging
+func (q QueryBuilder) SortColumn(param reqres.ReqPaging) string {
+	_, column := q.sortColumn(param)
+
+	return column
+}
+
+func (q QueryBuilder) sortColumn(param reqres.ReqPaging) (name, column string) {
+	name = param.Sort
+	column, ok := q.Sortable[name]
+	if !ok {
+		name = q.DefaultSort
+		column = q.Sortable[name]
+	}
+
+	return
+}
+
+func (q QueryBuilder) decodeCursor(param reqres.ReqPaging) (c pageCursor, value interface{}, err error) {
+	if c, err = decodeCursor(param.Cursor); err != nil {
+		return
+	}
+
+	name, _ := q.sortColumn(param)
+	if c.Sort != name || c.Order != param.Order {
+		err = errors.New("cursor was issued for a different sort order")
+		return
+	}
+
+	value, err = c.value()
+
+	return
+}
+
+func orderByColumn(db *gorm.DB, column string, desc bool) *gorm.DB {
+	db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: desc})
+	if column != "id" {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: desc})
+	}
+
+	return db
+}
+
+// trimRows cuts the slice out points to down to limit rows and reports whether it was longer
+func trimRows(out interface{}, limit int) bool {
+	rows := reflect.ValueOf(out).Elem()
+	if rows.Len() <= limit {
+		return false
+	}
+	rows.Set(rows.Slice(0, limit))
+
+	return true
+}
+
+func reverseRows(out interface{}) {
+	rows := reflect.ValueOf(out).Elem()
+	swap := reflect.Swapper(rows.Interface())
+	for i, j := 0, rows.Len()-1; i < j; i, j = i+1, j-1 {
+		swap(i, j)
+	}
+}
+
+// cursorRows reads the sort column and id of the first and last row of out
+func cursorRows(tx *gorm.DB, out interface{}, column string) (first, last *pageCursor, err error) {
+	rows := reflect.Indirect(reflect.ValueOf(out))
+	if rows.Len() == 0 {
+		return
+	}
+
+	sortField := tx.Statement.Schema.LookUpField(column)
+	idField := tx.Statement.Schema.LookUpField("id")
+	if sortField == nil || idField == nil {
+		err = fmt.Errorf("cannot page %s by cursor on %q", tx.Statement.Schema.Table, column)
+		return
+	}
+
+	read := func(row reflect.Value) (*pageCursor, error) {
+		row = reflect.Indirect(row)
+		value, _ := sortField.ValueOf(tx.Statement.Context, row)
+		id, _ := idField.ValueOf(tx.Statement.Context, row)
+
+		c := pageCursor{}
+		c.Kind, c.Value = cursorValue(value)
+		if c.Kind == "" {
+			return nil, fmt.Errorf("cannot page by cursor on empty %q", column)
+		}
+		rowID, ok := id.(uint)
+		if !ok {
+			return nil, fmt.Errorf("cannot page %s by cursor without a numeric id", tx.Statement.Schema.Table)
+		}
+		c.ID = rowID
+
+		return &c, nil
+	}
+
+	if first, err = read(rows.Index(0)); err != nil {
+		return
+	}
+	last, err = read(rows.Index(rows.Len() - 1))
+
+	return
+}
+
+func (q QueryBuilder) compileFilter(filter reqres.Filter) (clause.Expression, error) {
+	field, ok := q.Filterable[filter.Field]
+	if !ok {
+		return nil, errors.New("field cannot be filtered")
+	}
+
+	operator := filter.Operator
+	if operator == "" {
+		operator = FilterEq
+	}
+	if !IsStringInArray(operator, filterOperators[field.Type]) {
+		return nil, fmt.Errorf("operator %q is not supported for this field", operator)
+	}
+
+	column := clause.Column{Name: field.Column}
+
+	switch operator {
+	case FilterIsNull:
+		isNull, err := strconv.ParseBool(filter.Value)
+		if err != nil {
+			return nil, errors.New("must be true or false")
+		}
+		if isNull {
+			return clause.Eq{Column: column, Value: nil}, nil
+		}
+		return clause.Neq{Column: column, Value: nil}, nil
+	case FilterLike:
+		return clause.Expr{SQL: "? ILIKE ?", Vars: []interface{}{column, "%" + EscapeLike(filter.Value) + "%"}}, nil
+	case FilterIn:
+		values, err := parseFilterValues(field.Type, strings.Split(filter.Value, ","))
+		if err != nil {
+			return nil, err
+		}
+		return clause.IN{Column: column, Values: values}, nil
+	case FilterBetween:
+		parts := strings.Split(filter.Value, ",")
+		if len(parts) != 2 {
+			return nil, errors.New("must be two values separated by a comma")
+		}
+		values, err := parseFilterValues(field.Type, parts)
+		if err != nil {
+			return nil, err
+		}
+		return clause.Expr{SQL: "? BETWEEN ? AND ?", Vars: []interface{}{column, values[0], values[1]}}, nil
+	}
+
+	value, err := parseFilterValue(field.Type, filter.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operator {
+	case FilterNe:
+		return clause.Neq{Column: column, Value: value}, nil
+	case FilterLt:
+		return clause.Lt{Column: column, Value: value}, nil
+	case FilterLte:
+		return clause.Lte{Column: column, Value: value}, nil
+	case FilterGt:
+		return clause.Gt{Column: column, Value: value}, nil
+	case FilterGte:
+		return clause.Gte{Column: column, Value: value}, nil
+	default:
+		return clause.Eq{Column: column, Value: value}, nil
+	}
+}
+
+func parseFilterValues(fieldType FieldType, raw []string) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(raw))
+	for _, r := range raw {
+		value, err := parseFilterValue(fieldType, strings.TrimSpace(r))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func parseFilterValue(fieldType FieldType, raw string) (interface{}, error) {
+	switch fieldType {
+	case FieldInt:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, errors.New("must be a number")
+		}
+		return value, nil
+	case FieldBool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.New("must be true or false")
+		}
+		return value, nil
+	case FieldTime:
+		for _, layout := range filterTimeLayouts {
+			if value, err := time.Parse(layout, raw); err == nil {
+				return value, nil
+			}
+		}
+		return nil, errors.New("must be a date as YYYY-MM-DD or RFC 3339")
+	default:
+		return raw, nil
+	}
+}
+
+func filterParamName(filter reqres.Filter) string {
+	if filter.Operator == "" {
+		return "filter[" + filter.Field + "]"
+	}
+	return "filter[" + filter.Field + "][" + filter.Operator + "]"
+}
+
+// EscapeLike escapes the LIKE wildcards in s so it is matched literally
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
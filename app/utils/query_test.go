@@ -0,0 +1,297 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"project-name/app/reqres"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type testRow struct {
+	ID        uint
+	Name      string
+	Email     string
+	RoleID    int
+	IsVerify  bool
+	CreatedAt string
+}
+
+var testQuery = QueryBuilder{
+	Searchable: []string{"name", "email"},
+	Sortable: map[string]string{
+		"id":   "id",
+		"name": "name",
+	},
+	Filterable: map[string]FilterField{
+		"name":       {Column: "name", Type: FieldString},
+		"role_id":    {Column: "role_id", Type: FieldInt},
+		"is_verify":  {Column: "is_verify", Type: FieldBool},
+		"created_at": {Column: "created_at", Type: FieldTime},
+	},
+	DefaultSort: "id",
+}
+
+// dryRun builds statements without a database, the SQL keeps its placeholders
+func dryRun(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 port=1 sslmode=disable"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+// statement runs the scopes as a list query and returns its SQL and bound values
+func statement(t *testing.T, scopes ...func(*gorm.DB) *gorm.DB) (string, []interface{}) {
+	t.Helper()
+
+	stmt := dryRun(t).Model(&testRow{}).Scopes(scopes...).Find(&[]testRow{}).Statement
+
+	return stmt.SQL.String(), stmt.Vars
+}
+
+// paging reads the paging params of a request to target
+func paging(target string) reqres.ReqPaging {
+	c := echo.New().NewContext(httptest.NewRequest("GET", target, nil), httptest.NewRecorder())
+
+	return PopulatePaging(c, "")
+}
+
+func TestPopulatePaging(t *testing.T) {
+	tests := []struct {
+		target string
+		want   reqres.ReqPaging
+	}{
+		{"/", reqres.ReqPaging{Order: "DESC", Sort: "id", Limit: 10, Page: 1, Draw: 1, Count: true}},
+		{"/?page=3&limit=20&order=name&sort=asc&search=budi", reqres.ReqPaging{Order: "ASC", Sort: "name", Limit: 20, Offset: 40, Page: 3, Draw: 1, Search: "budi", Count: true}},
+		{"/?limit=-5&offset=-1&sort=sideways", reqres.ReqPaging{Order: "DESC", Sort: "id", Limit: 10, Page: 1, Draw: 1, Count: true}},
+		{"/?offset=30&limit=15", reqres.ReqPaging{Order: "DESC", Sort: "id", Limit: 15, Offset: 30, Draw: 1, Count: true}},
+		{"/?cursor=", reqres.ReqPaging{Order: "DESC", Sort: "id", Limit: 10, Page: 1, Draw: 1, CursorMode: true}},
+		{"/?limit=1000000&page=2", reqres.ReqPaging{Order: "DESC", Sort: "id", Limit: 100, Offset: 100, Page: 2, Draw: 1, Count: true}},
+		{"/?cursor=abc&count=true", reqres.ReqPaging{Order: "DESC", Sort: "id", Limit: 10, Page: 1, Draw: 1, CursorMode: true, Cursor: "abc", Count: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			got := paging(tt.target)
+			// the custom param and projections are not part of paging
+			got.Custom, got.Projection = nil, reqres.ReqProjection{}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderValidateSort(t *testing.T) {
+	tests := []struct {
+		name  string
+		sort  string
+		valid bool
+	}{
+		{"whitelisted", "name", true},
+		{"empty falls back to the default", "", true},
+		{"unknown field", "password", false},
+		{"column of another resource", "role_id", false},
+		{"injection", "id; DROP TABLE users--", false},
+		{"expression", "name DESC, (SELECT 1)", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testQuery.Validate(reqres.ReqPaging{Sort: tt.sort, Order: "ASC"})
+			if tt.valid {
+				if err != nil {
+					t.Fatalf("rejected: %v", err)
+				}
+				return
+			}
+
+			errs, ok := err.(validation.Errors)
+			if !ok || errs["order"] == nil {
+				t.Fatalf("accepted, err = %v", err)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderOrderBy(t *testing.T) {
+	tests := []struct {
+		name  string
+		param reqres.ReqPaging
+		want  string
+	}{
+		{"sort column with id tie breaker", reqres.ReqPaging{Sort: "name", Order: "ASC"}, `SELECT * FROM "test_rows" ORDER BY "name","id"`},
+		{"descending", reqres.ReqPaging{Sort: "name", Order: "DESC"}, `SELECT * FROM "test_rows" ORDER BY "name" DESC,"id" DESC`},
+		{"unknown column falls back to the default", reqres.ReqPaging{Sort: `name"; DROP TABLE users--`, Order: "ASC"}, `SELECT * FROM "test_rows" ORDER BY "id"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, _ := statement(t, testQuery.OrderBy(tt.param))
+			if sql != tt.want {
+				t.Errorf("sql = %s\nwant  %s", sql, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderSearch(t *testing.T) {
+	sql, vars := statement(t, testQuery.Where(reqres.ReqPaging{Search: `50%_off\'`}))
+
+	want := `SELECT * FROM "test_rows" WHERE ("name" ILIKE $1 OR "email" ILIKE $2)`
+	if sql != want {
+		t.Errorf("sql = %s\nwant  %s", sql, want)
+	}
+	pattern := `%50\%\_off\\'%`
+	if !reflect.DeepEqual(vars, []interface{}{pattern, pattern}) {
+		t.Errorf("vars = %q", vars)
+	}
+}
+
+func TestParseFilters(t *testing.T) {
+	got := paging("/?filter[role_id][GTE]=2&filter[name]=budi&filter[name][like]=x&filter[bad=1&filters[name]=y&filter[a][b][c]=1").Filters
+
+	want := []reqres.Filter{
+		{Field: "name", Value: "budi"},
+		{Field: "name", Operator: "like", Value: "x"},
+		{Field: "role_id", Operator: "gte", Value: "2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+}
+
+func TestQueryBuilderFilters(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter reqres.Filter
+		sql    string
+		vars   []interface{}
+		err    string
+	}{
+		{"eq by default", reqres.Filter{Field: "name", Value: "budi"}, `"name" = $1`, []interface{}{"budi"}, ""},
+		{"ne", reqres.Filter{Field: "role_id", Operator: "ne", Value: "3"}, `"role_id" <> $1`, []interface{}{int64(3)}, ""},
+		{"gte", reqres.Filter{Field: "role_id", Operator: "gte", Value: "2"}, `"role_id" >= $1`, []interface{}{int64(2)}, ""},
+		{"in", reqres.Filter{Field: "role_id", Operator: "in", Value: "1, 2,3"}, `"role_id" IN ($1,$2,$3)`, []interface{}{int64(1), int64(2), int64(3)}, ""},
+		{"between", reqres.Filter{Field: "role_id", Operator: "between", Value: "1,5"}, `"role_id" BETWEEN $1 AND $2`, []interface{}{int64(1), int64(5)}, ""},
+		{"like is escaped", reqres.Filter{Field: "name", Operator: "like", Value: "a%b"}, `"name" ILIKE $1`, []interface{}{`%a\%b%`}, ""},
+		{"isnull", reqres.Filter{Field: "name", Operator: "isnull", Value: "true"}, `"name" IS NULL`, []interface{}{}, ""},
+		{"not null", reqres.Filter{Field: "name", Operator: "isnull", Value: "false"}, `"name" IS NOT NULL`, []interface{}{}, ""},
+		{"bool", reqres.Filter{Field: "is_verify", Value: "1"}, `"is_verify" = $1`, []interface{}{true}, ""},
+		{"date", reqres.Filter{Field: "created_at", Operator: "lt", Value: "2026-01-02"}, `"created_at" < $1`, []interface{}{time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}, ""},
+		{"value cannot inject", reqres.Filter{Field: "name", Value: "x' OR '1'='1"}, `"name" = $1`, []interface{}{"x' OR '1'='1"}, ""},
+
+		{"unknown field", reqres.Filter{Field: "password", Value: "x"}, "", nil, "filter[password]: field cannot be filtered."},
+		{"field as sql", reqres.Filter{Field: "name = name OR 1", Value: "1"}, "", nil, "filter[name = name OR 1]: field cannot be filtered."},
+		{"unknown operator", reqres.Filter{Field: "role_id", Operator: "bad", Value: "1"}, "", nil, `filter[role_id][bad]: operator "bad" is not supported for this field.`},
+		{"operator of another type", reqres.Filter{Field: "is_verify", Operator: "gt", Value: "true"}, "", nil, `filter[is_verify][gt]: operator "gt" is not supported for this field.`},
+		{"like on a number", reqres.Filter{Field: "role_id", Operator: "like", Value: "1"}, "", nil, `filter[role_id][like]: operator "like" is not supported for this field.`},
+		{"not a number", reqres.Filter{Field: "role_id", Value: "1 OR 1=1"}, "", nil, "filter[role_id]: must be a number."},
+		{"not a number in a list", reqres.Filter{Field: "role_id", Operator: "in", Value: "1,x"}, "", nil, "filter[role_id][in]: must be a number."},
+		{"between needs two values", reqres.Filter{Field: "role_id", Operator: "between", Value: "1,2,3"}, "", nil, "filter[role_id][between]: must be two values separated by a comma."},
+		{"not a bool", reqres.Filter{Field: "is_verify", Value: "yes"}, "", nil, "filter[is_verify]: must be true or false."},
+		{"not a date", reqres.Filter{Field: "created_at", Value: "02/01/2026"}, "", nil, "filter[created_at]: must be a date as YYYY-MM-DD or RFC 3339."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param := reqres.ReqPaging{Filters: []reqres.Filter{tt.filter}}
+
+			err := testQuery.Validate(param)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("err = %v, want %s", err, tt.err)
+				}
+
+				// Where skips what Validate rejects
+				if sql, _ := statement(t, testQuery.Where(param)); sql != `SELECT * FROM "test_rows"` {
+					t.Errorf("sql = %s", sql)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("rejected: %v", err)
+			}
+
+			sql, vars := statement(t, testQuery.Where(param))
+			if want := `SELECT * FROM "test_rows" WHERE ` + tt.sql; sql != want {
+				t.Errorf("sql = %s\nwant  %s", sql, want)
+			}
+			if !reflect.DeepEqual(vars, tt.vars) {
+				t.Errorf("vars = %#v, want %#v", vars, tt.vars)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderFiltersCombine(t *testing.T) {
+	param := paging("/?search=bu&filter[role_id][in]=2,3&filter[is_verify]=true")
+	if err := testQuery.Validate(param); err != nil {
+		t.Fatal(err)
+	}
+
+	sql, vars := statement(t, testQuery.Where(param))
+	want := `SELECT * FROM "test_rows" WHERE ("name" ILIKE $1 OR "email" ILIKE $2) AND "is_verify" = $3 AND "role_id" IN ($4,$5)`
+	if sql != want {
+		t.Errorf("sql = %s\nwant  %s", sql, want)
+	}
+	if !reflect.DeepEqual(vars, []interface{}{"%bu%", "%bu%", true, int64(2), int64(3)}) {
+		t.Errorf("vars = %#v", vars)
+	}
+}
+
+func TestQueryBuilderFindCounts(t *testing.T) {
+	tests := []struct {
+		target string
+		counts []string
+	}{
+		{"/", []string{`SELECT count(*) FROM "test_rows" WHERE role_id = $1`}},
+		{"/?search=bu&filter[is_verify]=true", []string{
+			`SELECT count(*) FROM "test_rows" WHERE role_id = $1`,
+			`SELECT count(*) FROM "test_rows" WHERE role_id = $1 AND ("name" ILIKE $2 OR "email" ILIKE $3) AND "is_verify" = $4`,
+		}},
+		{"/?cursor=", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			db := dryRun(t)
+			var counts []string
+			db.Callback().Query().After("gorm:query").Register("test:record", func(tx *gorm.DB) {
+				if sql := tx.Statement.SQL.String(); strings.HasPrefix(sql, "SELECT count(*)") {
+					counts = append(counts, sql)
+				}
+			})
+
+			param := paging(tt.target)
+			if _, err := testQuery.Find(db.Model(&testRow{}).Where("role_id = ?", 2), param, &[]testRow{}); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(counts, tt.counts) {
+				t.Errorf("counts = %q\nwant     %q", counts, tt.counts)
+			}
+		})
+	}
+}
+
+func TestPopulatePageResPaging(t *testing.T) {
+	param := reqres.ReqPaging{Limit: 10, Offset: 10, Draw: 3}
+
+	got := PopulatePageResPaging(&param, []testRow{}, Page{Total: 120, Filtered: 25, Counted: true, HasNext: true, HasPrev: true})
+	if got.TotalData != 120 || got.RecordsFiltered != 25 || got.TotalPage != 3 || got.CurrentPage != 2 || got.Draw != 3 {
+		t.Errorf("counted: %+v", got)
+	}
+
+	got = PopulatePageResPaging(&param, []testRow{}, Page{HasNext: true})
+	if got.TotalData != -1 || got.RecordsFiltered != -1 || got.TotalPage != 0 || !got.Next {
+		t.Errorf("not counted: %+v", got)
+	}
+}
@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"project-name/app/repository"
+	"project-name/config"
+	"time"
+)
+
+// fileSweepBatchSize is how many orphans one transaction deletes
+const fileSweepBatchSize = 100
+
+// RunFileSweeper periodically deletes the uploaded files nothing refers to once
+// FILE_ORPHAN_GRACE has passed, and the expired resumable uploads, until the
+// process exits
+func RunFileSweeper() {
+	interval := time.Duration(config.LoadConfig().FileSweepInterval) * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Println("File sweeper started")
+
+	files := repository.NewFileRepository(config.DB)
+	uploads := repository.NewUploadRepository(config.DB)
+	for range ticker.C {
+		SweepUploads(uploads)
+		SweepFiles(files)
+	}
+}
+
+// SweepUploads deletes expired uploads and their chunks until none are left
+func SweepUploads(uploads repository.UploadRepository) {
+	for {
+		deleted, err := uploads.DeleteExpiredUploads(context.Background(), fileSweepBatchSize)
+		if err != nil {
+			log.Println("Failed to delete expired uploads. Error:", err)
+			return
+		}
+		if deleted > 0 {
+			log.Printf("Deleted %d expired uploads", deleted)
+		}
+
+		if deleted < fileSweepBatchSize {
+			return
+		}
+	}
+}
+
+// SweepFiles deletes orphaned files until none are left
+func SweepFiles(files repository.FileRepository) {
+	grace := time.Duration(config.LoadConfig().FileOrphanGrace) * time.Minute
+
+	for {
+		deleted, err := files.SweepOrphans(context.Background(), grace, fileSweepBatchSize)
+		if err != nil {
+			log.Println("Failed to sweep orphaned files. Error:", err)
+			return
+		}
+		if deleted > 0 {
+			log.Printf("Deleted %d orphaned files", deleted)
+		}
+
+		if deleted < fileSweepBatchSize {
+			return
+		}
+	}
+}
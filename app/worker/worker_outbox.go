@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"project-name/app/repository"
+	"project-name/config"
+	"time"
+)
+
+// outboxBatchSize is how many messages one poll claims
+const outboxBatchSize = 20
+
+// RunOutbox polls the outbox table and delivers due messages until the process exits
+func RunOutbox() {
+	interval := time.Duration(config.LoadConfig().OutboxPollInterval) * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Println("Outbox worker started")
+
+	outbox := repository.NewOutboxRepository(config.DB)
+	for range ticker.C {
+		DrainOutbox(outbox)
+	}
+}
+
+// DrainOutbox delivers due messages until none are left
+func DrainOutbox(outbox repository.OutboxRepository) {
+	ctx := context.Background()
+
+	for {
+		messages, err := outbox.ClaimOutboxMessages(ctx, outboxBatchSize)
+		if err != nil {
+			log.Println("Failed to claim outbox messages. Error:", err)
+			return
+		}
+
+		for _, message := range messages {
+			if err := outbox.DeliverOutboxMessage(ctx, message); err != nil {
+				log.Printf("Failed to deliver outbox message %d (attempt %d/%d). Error: %v", message.ID, message.Attempts+1, message.MaxAttempts, err)
+			}
+		}
+
+		if len(messages) < outboxBatchSize {
+			return
+		}
+	}
+}
@@ -48,6 +48,7 @@ type Config struct {
 	EnableConcurrent            bool
 	EnableCSRF                  bool
 	EnableDatabaseAutomigration bool
+	AllowPendingMigrations      bool
 	EnableSaas                  bool
 	EnableAPIKey                bool
 	APIKey                      string
@@ -72,6 +73,52 @@ type Config struct {
 	ComputerAppData             string
 	IcanDelivEmail              string
 	IcanDelivPassword           string
+	JwtSigningMethod            string
+	JwtKeyID                    string
+	JwtSecret                   string
+	JwtPrivateKeyPath           string
+	JwtRetiredKeys              string
+	JwtAccessTokenTTL           int
+	JwtRefreshTokenTTL          int
+	SessionStore                string
+	PasswordResetTokenTTL       int
+	EmailVerificationTokenTTL   int
+	EmailVerificationThrottle   int
+	RequireEmailVerification    bool
+	MailDriver                  string
+	MailOutboxDir               string
+	EnableOutboxWorker          bool
+	OutboxPollInterval          int
+	OutboxMaxAttempts           int
+	OutboxBaseBackoff           int
+	SeedAdminName               string
+	SeedAdminEmail              string
+	SeedAdminPassword           string
+	RegionCacheMaxAge           int
+	StorageDriver               string
+	StorageURLTTL               int
+	S3Endpoint                  string
+	S3Region                    string
+	S3Bucket                    string
+	S3AccessKey                 string
+	S3SecretKey                 string
+	S3ForcePathStyle            bool
+	EnableFileSweeper           bool
+	FileSweepInterval           int
+	FileOrphanGrace             int
+	EnableImageProcessing       bool
+	ImageVariants               string
+	ImageVariantFormat          string
+	ImageQuality                int
+	ImageMaxPixels              int
+	ImageWebPEncoder            string
+	UploadMaxSize               int64
+	UploadExpiry                int
+	FileShareMaxTTL             int
+	ScannerDriver               string
+	ClamAVAddress               string
+	ClamAVTimeout               int
+	PageMaxLimit                int
 }
 
 func LoadConfig() (config *Config) {
@@ -114,6 +161,7 @@ func LoadConfig() (config *Config) {
 	enableConcurrent, _ := strconv.ParseBool(os.Getenv("ENABLE_CONCURRENT"))
 	enableCSRF, _ := strconv.ParseBool(os.Getenv("ENABLE_CSRF"))
 	enableDatabaseAutomigration, _ := strconv.ParseBool(os.Getenv("ENABLE_DATABASE_AUTOMIGRATION"))
+	allowPendingMigrations, _ := strconv.ParseBool(os.Getenv("ALLOW_PENDING_MIGRATIONS"))
 	enableSaas, _ := strconv.ParseBool(os.Getenv("ENABLE_SAAS"))
 	enableApiKey, _ := strconv.ParseBool(os.Getenv("ENABLE_API_KEY"))
 	goldAPIUrl := os.Getenv("GOLDAPI_URL")
@@ -137,6 +185,147 @@ func LoadConfig() (config *Config) {
 	apiKey := os.Getenv("API_KEY")
 	icanDelivEmail := os.Getenv("ICAN_DELIV_EMAIL")
 	icanDelivPassword := os.Getenv("ICAN_DELIV_PASSWORD")
+	jwtSigningMethod := strings.ToUpper(os.Getenv("JWT_SIGNING_METHOD"))
+	jwtKeyID := os.Getenv("JWT_KEY_ID")
+	jwtSecret := os.Getenv("JWT_SECRET")
+	jwtPrivateKeyPath := os.Getenv("JWT_PRIVATE_KEY_PATH")
+	jwtRetiredKeys := os.Getenv("JWT_RETIRED_KEYS")
+	jwtAccessTokenTTL, _ := strconv.Atoi(os.Getenv("JWT_ACCESS_TOKEN_TTL"))
+	jwtRefreshTokenTTL, _ := strconv.Atoi(os.Getenv("JWT_REFRESH_TOKEN_TTL"))
+	sessionStore := strings.ToUpper(os.Getenv("SESSION_STORE"))
+	passwordResetTokenTTL, _ := strconv.Atoi(os.Getenv("PASSWORD_RESET_TOKEN_TTL"))
+	emailVerificationTokenTTL, _ := strconv.Atoi(os.Getenv("EMAIL_VERIFICATION_TOKEN_TTL"))
+	emailVerificationThrottle, _ := strconv.Atoi(os.Getenv("EMAIL_VERIFICATION_THROTTLE"))
+	requireEmailVerification, _ := strconv.ParseBool(os.Getenv("REQUIRE_EMAIL_VERIFICATION"))
+	mailDriver := strings.ToUpper(os.Getenv("MAIL_DRIVER"))
+	mailOutboxDir := os.Getenv("MAIL_OUTBOX_DIR")
+	enableOutboxWorker, err := strconv.ParseBool(os.Getenv("ENABLE_OUTBOX_WORKER"))
+	if err != nil {
+		enableOutboxWorker = true
+	}
+	outboxPollInterval, _ := strconv.Atoi(os.Getenv("OUTBOX_POLL_INTERVAL"))
+	outboxMaxAttempts, _ := strconv.Atoi(os.Getenv("OUTBOX_MAX_ATTEMPTS"))
+	outboxBaseBackoff, _ := strconv.Atoi(os.Getenv("OUTBOX_BASE_BACKOFF"))
+	seedAdminName := os.Getenv("SEED_ADMIN_NAME")
+	seedAdminEmail := os.Getenv("SEED_ADMIN_EMAIL")
+	seedAdminPassword := os.Getenv("SEED_ADMIN_PASSWORD")
+	regionCacheMaxAge, _ := strconv.Atoi(os.Getenv("REGION_CACHE_MAX_AGE"))
+	storageDriver := strings.ToUpper(os.Getenv("STORAGE_DRIVER"))
+	storageURLTTL, _ := strconv.Atoi(os.Getenv("STORAGE_URL_TTL"))
+	s3Endpoint := os.Getenv("S3_ENDPOINT")
+	s3Region := os.Getenv("S3_REGION")
+	s3Bucket := os.Getenv("S3_BUCKET")
+	s3AccessKey := os.Getenv("S3_ACCESS_KEY")
+	s3SecretKey := os.Getenv("S3_SECRET_KEY")
+	s3ForcePathStyle, _ := strconv.ParseBool(os.Getenv("S3_FORCE_PATH_STYLE"))
+	enableFileSweeper, err := strconv.ParseBool(os.Getenv("ENABLE_FILE_SWEEPER"))
+	if err != nil {
+		enableFileSweeper = true
+	}
+	fileSweepInterval, _ := strconv.Atoi(os.Getenv("FILE_SWEEP_INTERVAL"))
+	fileOrphanGrace, _ := strconv.Atoi(os.Getenv("FILE_ORPHAN_GRACE"))
+	enableImageProcessing, err := strconv.ParseBool(os.Getenv("ENABLE_IMAGE_PROCESSING"))
+	if err != nil {
+		enableImageProcessing = true
+	}
+	imageVariants, hasImageVariants := os.LookupEnv("IMAGE_VARIANTS")
+	imageVariantFormat := os.Getenv("IMAGE_VARIANT_FORMAT")
+	imageQuality, _ := strconv.Atoi(os.Getenv("IMAGE_QUALITY"))
+	imageMaxPixels, _ := strconv.Atoi(os.Getenv("IMAGE_MAX_PIXELS"))
+	imageWebPEncoder := os.Getenv("IMAGE_WEBP_ENCODER")
+	uploadMaxSize, _ := strconv.ParseInt(os.Getenv("UPLOAD_MAX_SIZE"), 10, 64)
+	uploadExpiry, _ := strconv.Atoi(os.Getenv("UPLOAD_EXPIRY"))
+	fileShareMaxTTL, _ := strconv.Atoi(os.Getenv("FILE_SHARE_MAX_TTL"))
+	scannerDriver := strings.ToUpper(os.Getenv("SCANNER_DRIVER"))
+	clamAVAddress := os.Getenv("CLAMAV_ADDRESS")
+	clamAVTimeout, _ := strconv.Atoi(os.Getenv("CLAMAV_TIMEOUT"))
+	pageMaxLimit, _ := strconv.Atoi(os.Getenv("PAGE_MAX_LIMIT"))
+
+	if jwtSigningMethod == "" {
+		jwtSigningMethod = "HS256"
+	}
+	if jwtKeyID == "" {
+		jwtKeyID = "default"
+	}
+	if jwtSecret == "" {
+		jwtSecret = appKey
+	}
+	if jwtAccessTokenTTL == 0 {
+		jwtAccessTokenTTL = 1440
+	}
+	if jwtRefreshTokenTTL == 0 {
+		jwtRefreshTokenTTL = 43200
+	}
+	if passwordResetTokenTTL == 0 {
+		passwordResetTokenTTL = 60
+	}
+	if emailVerificationTokenTTL == 0 {
+		emailVerificationTokenTTL = 1440
+	}
+	if emailVerificationThrottle == 0 {
+		emailVerificationThrottle = 60
+	}
+	if mailOutboxDir == "" {
+		mailOutboxDir = "outbox"
+	}
+	if outboxPollInterval == 0 {
+		outboxPollInterval = 5
+	}
+	if outboxMaxAttempts == 0 {
+		outboxMaxAttempts = 8
+	}
+	if outboxBaseBackoff == 0 {
+		outboxBaseBackoff = 30
+	}
+	if regionCacheMaxAge == 0 {
+		regionCacheMaxAge = 604800
+	}
+	if storageDriver == "" {
+		storageDriver = "LOCAL"
+	}
+	if storageURLTTL == 0 {
+		storageURLTTL = 3600
+	}
+	if fileSweepInterval == 0 {
+		fileSweepInterval = 3600
+	}
+	if fileOrphanGrace == 0 {
+		fileOrphanGrace = 1440
+	}
+	// an empty IMAGE_VARIANTS turns variants off, only an unset one gets the default
+	if !hasImageVariants {
+		imageVariants = "thumb:150x150,medium:640x640,large:1280x1280"
+	}
+	if imageQuality == 0 {
+		imageQuality = 85
+	}
+	if imageMaxPixels == 0 {
+		imageMaxPixels = 50000000
+	}
+	if imageWebPEncoder == "" {
+		imageWebPEncoder = "cwebp"
+	}
+	if uploadMaxSize == 0 {
+		uploadMaxSize = 1 << 30
+	}
+	if uploadExpiry == 0 {
+		uploadExpiry = 24
+	}
+	if fileShareMaxTTL == 0 {
+		fileShareMaxTTL = 7 * 24 * 3600
+	}
+	if scannerDriver == "" {
+		scannerDriver = "NONE"
+	}
+	if clamAVAddress == "" {
+		clamAVAddress = "tcp:localhost:3310"
+	}
+	if clamAVTimeout == 0 {
+		clamAVTimeout = 30
+	}
+	if pageMaxLimit <= 0 {
+		pageMaxLimit = 100
+	}
 
 	var isDesktop bool
 	if environment == "DESKTOP" {
@@ -180,6 +369,7 @@ func LoadConfig() (config *Config) {
 		EnableConcurrent:            enableConcurrent,
 		EnableCSRF:                  enableCSRF,
 		EnableDatabaseAutomigration: enableDatabaseAutomigration,
+		AllowPendingMigrations:      allowPendingMigrations,
 		EnableSaas:                  enableSaas,
 		IsDesktop:                   isDesktop,
 		GoldAPIUrl:                  goldAPIUrl,
@@ -204,6 +394,52 @@ func LoadConfig() (config *Config) {
 		APIKey:                      apiKey,
 		IcanDelivEmail:              icanDelivEmail,
 		IcanDelivPassword:           icanDelivPassword,
+		JwtSigningMethod:            jwtSigningMethod,
+		JwtKeyID:                    jwtKeyID,
+		JwtSecret:                   jwtSecret,
+		JwtPrivateKeyPath:           jwtPrivateKeyPath,
+		JwtRetiredKeys:              jwtRetiredKeys,
+		JwtAccessTokenTTL:           jwtAccessTokenTTL,
+		JwtRefreshTokenTTL:          jwtRefreshTokenTTL,
+		SessionStore:                sessionStore,
+		PasswordResetTokenTTL:       passwordResetTokenTTL,
+		EmailVerificationTokenTTL:   emailVerificationTokenTTL,
+		EmailVerificationThrottle:   emailVerificationThrottle,
+		RequireEmailVerification:    requireEmailVerification,
+		MailDriver:                  mailDriver,
+		MailOutboxDir:               mailOutboxDir,
+		EnableOutboxWorker:          enableOutboxWorker,
+		OutboxPollInterval:          outboxPollInterval,
+		OutboxMaxAttempts:           outboxMaxAttempts,
+		OutboxBaseBackoff:           outboxBaseBackoff,
+		SeedAdminName:               seedAdminName,
+		SeedAdminEmail:              seedAdminEmail,
+		SeedAdminPassword:           seedAdminPassword,
+		RegionCacheMaxAge:           regionCacheMaxAge,
+		StorageDriver:               storageDriver,
+		StorageURLTTL:               storageURLTTL,
+		S3Endpoint:                  s3Endpoint,
+		S3Region:                    s3Region,
+		S3Bucket:                    s3Bucket,
+		S3AccessKey:                 s3AccessKey,
+		S3SecretKey:                 s3SecretKey,
+		S3ForcePathStyle:            s3ForcePathStyle,
+		EnableFileSweeper:           enableFileSweeper,
+		FileSweepInterval:           fileSweepInterval,
+		FileOrphanGrace:             fileOrphanGrace,
+		EnableImageProcessing:       enableImageProcessing,
+		ImageVariants:               imageVariants,
+		ImageVariantFormat:          imageVariantFormat,
+		ImageQuality:                imageQuality,
+		ImageMaxPixels:              imageMaxPixels,
+		ImageWebPEncoder:            imageWebPEncoder,
+		UploadMaxSize:               uploadMaxSize,
+		UploadExpiry:                uploadExpiry,
+		FileShareMaxTTL:             fileShareMaxTTL,
+		ScannerDriver:               scannerDriver,
+		ClamAVAddress:               clamAVAddress,
+		ClamAVTimeout:               clamAVTimeout,
+		PageMaxLimit:                pageMaxLimit,
 	}
 }
 
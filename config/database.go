@@ -28,20 +28,16 @@ func Database() *gorm.DB {
 	if dsn == "" {
 		dsn = fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable", host, user, password, name, port)
 	}
-	fmt.Sprintln("dsn:", dsn)
 	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
 		panic(err)
 	}
 
-	if LoadConfig().EnableDatabaseAutomigration {
-		err = DB.AutoMigrate(
-			&models.User{},
-		)
-		if err != nil {
-			fmt.Println(err)
-			panic("Migration Failed")
-		}
+	// Schema changes are versioned migrations, see the migrations directory. The join
+	// table still has to be registered so GORM fills its created_at.
+	err = DB.SetupJoinTable(&models.Role{}, "Permissions", &models.RolePermission{})
+	if err != nil {
+		panic(err)
 	}
 
 	fmt.Println("Connected to Database:", LoadConfig().DatabaseName)
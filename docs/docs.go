@@ -15,70 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/about-me": {
-            "get": {
-                "description": "Get About Me",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "AboutMe"
-                ],
-                "summary": "Get About Me",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Type",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "search By Title",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "limit default 10",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "offset",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "sort ASC or DESC",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "order by id",
-                        "name": "order",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
+        "/v1/auth/change-password-login": {
+            "put": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Create About Me",
+                "description": "Change Password Login",
                 "consumes": [
                     "application/json"
                 ],
@@ -86,17 +30,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AboutMe"
+                    "Auth"
                 ],
-                "summary": "Create About Me",
+                "summary": "Change Password Login",
                 "parameters": [
                     {
-                        "description": "Create About Me Request",
-                        "name": "request",
+                        "description": "Change Password Request",
+                        "name": "req",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqres.AboutMeRequest"
+                            "$ref": "#/definitions/reqres.ChangePassword"
                         }
                     }
                 ],
@@ -107,9 +51,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/about-me/{id}": {
-            "get": {
-                "description": "Get About Me By ID",
+        "/v1/auth/email-verify": {
+            "post": {
+                "security": [
+                    {
+                        "JwtToken": []
+                    }
+                ],
+                "description": "Send a new verification link to the current user's email",
                 "consumes": [
                     "application/json"
                 ],
@@ -117,31 +66,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AboutMe"
-                ],
-                "summary": "Get About Me By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "About Me ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Auth"
                 ],
+                "summary": "Send Email Verify Email",
                 "responses": {
                     "200": {
                         "description": "OK"
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update About Me",
+            }
+        },
+        "/v1/auth/forgot-password": {
+            "post": {
+                "description": "Email a single use reset password link. The response is the same whether the email is registered or not.",
                 "consumes": [
                     "application/json"
                 ],
@@ -149,24 +86,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AboutMe"
+                    "Auth"
                 ],
-                "summary": "Update About Me",
+                "summary": "Forgot Password",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "About Me ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update About Me Request",
+                        "description": "Forgot Password Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqres.AboutMeRequest"
+                            "$ref": "#/definitions/reqres.ForgotPasswordRequest"
                         }
                     }
                 ],
@@ -175,14 +105,16 @@ const docTemplate = `{
                         "description": "OK"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/auth/login/admin": {
+            "post": {
                 "security": [
                     {
-                        "JwtToken": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete About Me",
+                "description": "Login Admin",
                 "consumes": [
                     "application/json"
                 ],
@@ -190,38 +122,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AboutMe"
+                    "Auth"
                 ],
-                "summary": "Delete About Me",
+                "summary": "Login Admin",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "About Me ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
+                        "description": "Login Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqres.LoginRequest"
+                        }
                     }
-                }
-            }
-        },
-        "/v1/auth/activate-account/{id}": {
-            "put": {
-                "description": "Aktivate Account",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Auth"
                 ],
-                "summary": "Aktivate Account",
                 "responses": {
                     "200": {
                         "description": "OK"
@@ -229,14 +143,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/change-password-login": {
-            "put": {
+        "/v1/auth/login/user": {
+            "post": {
                 "security": [
                     {
-                        "JwtToken": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Change Password Login",
+                "description": "Login User",
                 "consumes": [
                     "application/json"
                 ],
@@ -246,15 +160,15 @@ const docTemplate = `{
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Change Password Login",
+                "summary": "Login User",
                 "parameters": [
                     {
-                        "description": "Change Password Request",
-                        "name": "req",
+                        "description": "Login Request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqres.ChangePassword"
+                            "$ref": "#/definitions/reqres.LoginRequest"
                         }
                     }
                 ],
@@ -265,14 +179,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/email-verify": {
+        "/v1/auth/logout": {
             "post": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Send Email Verify Email",
+                "description": "Revoke the current session",
                 "consumes": [
                     "application/json"
                 ],
@@ -282,7 +196,7 @@ const docTemplate = `{
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Send Email Verify Email",
+                "summary": "Logout",
                 "responses": {
                     "200": {
                         "description": "OK"
@@ -290,9 +204,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/forgot-password": {
+        "/v1/auth/logout-all": {
             "post": {
-                "description": "Forgot Password",
+                "security": [
+                    {
+                        "JwtToken": []
+                    }
+                ],
+                "description": "Revoke every session of the current user, including this one",
                 "consumes": [
                     "application/json"
                 ],
@@ -302,18 +221,7 @@ const docTemplate = `{
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Forgot Password",
-                "parameters": [
-                    {
-                        "description": "Forgot Password Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.ForgotPasswordRequest"
-                        }
-                    }
-                ],
+                "summary": "Logout All",
                 "responses": {
                     "200": {
                         "description": "OK"
@@ -321,14 +229,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/login/admin": {
+        "/v1/auth/refresh": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Login Admin",
+                "description": "Exchange a refresh token for a new access token. The refresh token is rotated on every call.",
                 "consumes": [
                     "application/json"
                 ],
@@ -338,15 +241,15 @@ const docTemplate = `{
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Login Admin",
+                "summary": "Refresh Token",
                 "parameters": [
                     {
-                        "description": "Login Request",
+                        "description": "Refresh Token Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqres.LoginRequest"
+                            "$ref": "#/definitions/reqres.RefreshTokenRequest"
                         }
                     }
                 ],
@@ -357,14 +260,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/login/user": {
+        "/v1/auth/register": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Login User",
+                "description": "Register",
                 "consumes": [
                     "application/json"
                 ],
@@ -374,15 +272,15 @@ const docTemplate = `{
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Login User",
+                "summary": "Register",
                 "parameters": [
                     {
-                        "description": "Login Request",
+                        "description": "Register Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqres.LoginRequest"
+                            "$ref": "#/definitions/reqres.UserRequest"
                         }
                     }
                 ],
@@ -393,9 +291,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/register": {
+        "/v1/auth/resend-verification": {
             "post": {
-                "description": "Register",
+                "description": "Send a new verification link without being logged in. The response is the same whether the email is registered or not.",
                 "consumes": [
                     "application/json"
                 ],
@@ -405,15 +303,15 @@ const docTemplate = `{
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Register",
+                "summary": "Resend Verification Email",
                 "parameters": [
                     {
-                        "description": "Register Request",
+                        "description": "Resend Verification Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqres.UserRequest"
+                            "$ref": "#/definitions/reqres.ResendVerificationRequest"
                         }
                     }
                 ],
@@ -424,9 +322,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/reset-password/{id}": {
+        "/v1/auth/reset-password": {
             "put": {
-                "description": "Reset Password",
+                "description": "Set a new password with the token from the forgot password email",
                 "consumes": [
                     "application/json"
                 ],
@@ -444,7 +342,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqres.ChangePassword"
+                            "$ref": "#/definitions/reqres.ResetPasswordRequest"
                         }
                     }
                 ],
@@ -455,14 +353,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/cart": {
-            "post": {
+        "/v1/auth/sessions": {
+            "get": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Create Cart",
+                "description": "List active sessions of the current user",
                 "consumes": [
                     "application/json"
                 ],
@@ -470,20 +368,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cart"
-                ],
-                "summary": "Create Cart",
-                "parameters": [
-                    {
-                        "description": "Create Cart Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.CartRequest"
-                        }
-                    }
+                    "Auth"
                 ],
+                "summary": "Get Sessions",
                 "responses": {
                     "200": {
                         "description": "OK"
@@ -491,14 +378,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/cart/user": {
-            "get": {
+        "/v1/auth/sessions/{id}": {
+            "delete": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Cart By User ID",
+                "description": "Revoke one session of the current user",
                 "consumes": [
                     "application/json"
                 ],
@@ -506,9 +393,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cart"
+                    "Auth"
+                ],
+                "summary": "Revoke Session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get Cart By User ID",
                 "responses": {
                     "200": {
                         "description": "OK"
@@ -516,14 +412,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/cart/{id}": {
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Cart",
+        "/v1/auth/verify-email": {
+            "post": {
+                "description": "Verify the email address with the token from the verification email",
                 "consumes": [
                     "application/json"
                 ],
@@ -531,24 +422,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cart"
+                    "Auth"
                 ],
-                "summary": "Update Cart",
+                "summary": "Verify Email",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Cart ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Cart Request",
+                        "description": "Verify Email Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqres.CartRequest"
+                            "$ref": "#/definitions/reqres.VerifyEmailRequest"
                         }
                     }
                 ],
@@ -557,14 +441,16 @@ const docTemplate = `{
                         "description": "OK"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/file": {
+            "get": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Delete Cart",
+                "description": "List the files uploaded by the logged in user",
                 "consumes": [
                     "application/json"
                 ],
@@ -572,57 +458,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cart"
+                    "File"
                 ],
-                "summary": "Delete Cart",
+                "summary": "Get My Files",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Cart ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/dashboard/businessinsight/addtocart": {
-            "get": {
-                "security": [
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
                     {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Dashboard Business Insight Add To Cart",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "DashboardBusinessInsight"
-                ],
-                "summary": "Get Dashboard Business Insight Add To Cart",
-                "parameters": [
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Start Date",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
+                        "description": "Search",
+                        "name": "search",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "End Date",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
+                        "description": "Sort",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter, e.g. filter[mime_type]=image/png, filter[ref_count]=0",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset cursor from next_cursor or prev_cursor, send it empty for the first page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the total, defaults to true with offset paging and false with a cursor",
+                        "name": "count",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -632,119 +518,105 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/dashboard/businessinsight/order": {
-            "get": {
+        "/v1/file/tus": {
+            "post": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Dashboard Business Insight Order",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Start a tus upload. The Location header is the URL to PATCH the content to, in chunks. Uploads not completed before Upload-Expires are deleted.",
                 "tags": [
-                    "DashboardBusinessInsight"
+                    "File"
                 ],
-                "summary": "Get Dashboard Business Insight Order",
+                "summary": "Create Resumable Upload",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start Date",
-                        "name": "start_date",
-                        "in": "query",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "End Date",
-                        "name": "end_date",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "Size of the whole file in bytes",
+                        "name": "Upload-Length",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated key and base64 value pairs, e.g. filename ZG9jLnBkZg==,filetype YXBwbGljYXRpb24vcGRm",
+                        "name": "Upload-Metadata",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "201": {
+                        "description": "Created"
                     }
                 }
             }
         },
-        "/v1/dashboard/businessinsight/sales": {
-            "get": {
+        "/v1/file/tus/{id}": {
+            "delete": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Dashboard Business Insight Sales",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Cancel an upload and delete the received chunks. A file already stored from it is kept.",
                 "tags": [
-                    "DashboardBusinessInsight"
+                    "File"
                 ],
-                "summary": "Get Dashboard Business Insight Sales",
+                "summary": "Terminate Resumable Upload",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start Date",
-                        "name": "start_date",
-                        "in": "query",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "End Date",
-                        "name": "end_date",
-                        "in": "query",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "204": {
+                        "description": "No Content"
                     }
                 }
-            }
-        },
-        "/v1/dashboard/businessinsight/user": {
-            "get": {
+            },
+            "head": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Dashboard Business Insight User",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Upload-Offset is how many bytes were received, PATCH the rest from there. Once complete, Upload-File-Id and Upload-File-Key name the stored file.",
                 "tags": [
-                    "DashboardBusinessInsight"
+                    "File"
                 ],
-                "summary": "Get Dashboard Business Insight User",
+                "summary": "Get Resumable Upload Offset",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start Date",
-                        "name": "start_date",
-                        "in": "query",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "End Date",
-                        "name": "end_date",
-                        "in": "query",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -753,80 +625,75 @@ const docTemplate = `{
                         "description": "OK"
                     }
                 }
-            }
-        },
-        "/v1/dashboard/keymetric/addtocart": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Dashboard Key Metric Add To Cart",
+                "description": "Append a chunk at Upload-Offset, which must equal the offset returned by HEAD, and be sent with Content-Length, 411 otherwise. The chunk completing the upload stores the file, once its type is checked, and answers with Upload-File-Id and Upload-File-Key, a concurrent request completing the same upload gets 409.",
                 "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
+                    "application/offset+octet-stream"
                 ],
                 "tags": [
-                    "DashboardKeyMetric"
+                    "File"
                 ],
-                "summary": "Get Dashboard Key Metric Add To Cart",
+                "summary": "Upload Chunk",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start Date",
-                        "name": "start_date",
-                        "in": "query",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of the chunk",
+                        "name": "Upload-Offset",
+                        "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "End Date",
-                        "name": "end_date",
-                        "in": "query",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/v1/dashboard/keymetric/order": {
-            "get": {
+        "/v1/file/upload": {
+            "post": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Dashboard Key Metric Order",
+                "description": "Upload a file owned by the logged in user. JPEG and PNG images are turned upright, stripped of metadata and resized into the configured variants. Set its key on a model, e.g. the user image, to keep it: files nothing refers to are deleted after a grace period. Files the malware scanner flags are rejected with 422, 503 means the scanner could not be reached.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "DashboardKeyMetric"
+                    "File"
                 ],
-                "summary": "Get Dashboard Key Metric Order",
+                "summary": "Upload File",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Start Date",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date",
-                        "name": "end_date",
-                        "in": "query",
+                        "type": "file",
+                        "description": "File to upload (PDF, JPEG, JPG, PNG)",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     }
                 ],
@@ -837,37 +704,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/dashboard/keymetric/sales": {
-            "get": {
+        "/v1/file/upload-multiple": {
+            "post": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Dashboard Key Metric Sales",
+                "description": "Upload files owned by the logged in user, see Upload File",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "DashboardKeyMetric"
+                    "File"
                 ],
-                "summary": "Get Dashboard Key Metric Sales",
+                "summary": "Upload Multiple Files",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Start Date",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date",
-                        "name": "end_date",
-                        "in": "query",
+                        "type": "file",
+                        "description": "Files to upload (PDF, JPEG, JPG, PNG)",
+                        "name": "files",
+                        "in": "formData",
                         "required": true
                     }
                 ],
@@ -878,14 +738,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/dashboard/keymetric/user": {
-            "get": {
+        "/v1/file/{id}": {
+            "delete": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Dashboard Key Metric User",
+                "description": "Delete a file uploaded by the logged in user. Files still in use are refused with 409.",
                 "consumes": [
                     "application/json"
                 ],
@@ -893,22 +753,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "DashboardKeyMetric"
+                    "File"
                 ],
-                "summary": "Get Dashboard Key Metric User",
+                "summary": "Delete My File",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Start Date",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date",
-                        "name": "end_date",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -919,83 +772,78 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/dashboard/rangking/penjualanproductteratas": {
+        "/v1/files/{id}/download": {
             "get": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Dashboard Penjualan Product Teratas",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Download a file, or one of its image variants, with support for Range requests. Either send the token of the owner or of a user with the file.read permission, or use a link from Share File, which needs neither the token nor the API key.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "DashboardRangking"
+                    "File"
                 ],
-                "summary": "Get Dashboard Penjualan Product Teratas",
+                "summary": "Download File",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Start Date",
-                        "name": "start_date",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "End Date",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
+                        "description": "Image variant, e.g. thumb",
+                        "name": "variant",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Sort DESC/ASC",
-                        "name": "sort",
-                        "in": "query",
-                        "required": true
+                        "description": "attachment (default) or inline, which only applies to JPEG, PNG and WebP images",
+                        "name": "disposition",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Category ID",
-                        "name": "category_id",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "Expiry of a shared link",
+                        "name": "expires",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "description": "Signature of a shared link",
+                        "name": "signature",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Order price/total",
-                        "name": "order",
-                        "in": "query",
-                        "required": true
+                        "description": "Byte range, e.g. bytes=0-1023",
+                        "name": "Range",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK"
+                    },
+                    "206": {
+                        "description": "Partial Content"
                     }
                 }
             }
         },
-        "/v1/dashboard/rangking/pesananperkategori": {
-            "get": {
+        "/v1/files/{id}/share": {
+            "post": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Dashboard Pesanan Per Kategori",
+                "description": "Create a signed link downloading the file, or one of its image variants, without signing in until it expires",
                 "consumes": [
                     "application/json"
                 ],
@@ -1003,37 +851,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "DashboardRangking"
+                    "File"
                 ],
-                "summary": "Get Dashboard Pesanan Per Kategori",
+                "summary": "Share File",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Start Date",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort DESC/ASC",
-                        "name": "sort",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "description": "Share File Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqres.FileShareRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1043,14 +879,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/dashboard/rangking/userorderteratas": {
+        "/v1/me": {
             "get": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Dashboard User Order Teratas",
+                "description": "Get the profile of the logged in user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1058,37 +894,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "DashboardRangking"
+                    "Me"
                 ],
-                "summary": "Get Dashboard User Order Teratas",
+                "summary": "Get Me",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start Date",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort DESC/ASC",
-                        "name": "sort",
-                        "in": "query",
-                        "required": true
+                        "description": "Comma separated fields to return, e.g. id,name,email",
+                        "name": "fields",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "description": "Comma separated relations to load: role, province, city, subdistrict",
+                        "name": "expand",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1096,16 +916,14 @@ const docTemplate = `{
                         "description": "OK"
                     }
                 }
-            }
-        },
-        "/v1/dashboard/todolist/cancelshipment": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Dashboard Todo List Cancel Shipment",
+                "description": "Update the profile of the logged in user. Email, role and verification cannot be changed here. Sending any of prov, kab and kec replaces the whole address, which must follow the province, city, subdistrict hierarchy.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1113,23 +931,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "DashboardToDoList"
+                    "Me"
                 ],
-                "summary": "Get Dashboard Todo List Cancel Shipment",
+                "summary": "Update Me",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Start Date",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
+                        "description": "Update Me Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqres.MeUpdateRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1139,37 +952,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/dashboard/todolist/processedshipment": {
-            "get": {
+        "/v1/me/avatar": {
+            "post": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Dashboard Todo List Processed Shipment",
+                "description": "Replace the avatar of the logged in user. Only JPEG and PNG are accepted.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "DashboardToDoList"
+                    "Me"
                 ],
-                "summary": "Get Dashboard Todo List Processed Shipment",
+                "summary": "Upload My Avatar",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Start Date",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date",
-                        "name": "end_date",
-                        "in": "query",
+                        "type": "file",
+                        "description": "Avatar image",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     }
                 ],
@@ -1180,14 +986,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/dashboard/todolist/toprocessshipment": {
-            "get": {
+        "/v1/me/email": {
+            "post": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Dashboard Todo List To Process Shipment",
+                "description": "Send a verification link to the new email. The email is changed once the link is followed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1195,23 +1001,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "DashboardToDoList"
+                    "Me"
                 ],
-                "summary": "Get Dashboard Todo List To Process Shipment",
+                "summary": "Change My Email",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Start Date",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
+                        "description": "Change Email Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqres.ChangeEmailRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1221,9 +1022,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/event": {
+        "/v1/outbox": {
             "get": {
-                "description": "Get All Event",
+                "security": [
+                    {
+                        "JwtToken": []
+                    }
+                ],
+                "description": "Get Outbox Messages",
                 "consumes": [
                     "application/json"
                 ],
@@ -1231,22 +1037,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Event"
+                    "Outbox"
+                ],
+                "summary": "Get Outbox Messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Status (pending, processing, sent, dead)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter, e.g. filter[attempts][gte]=3",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset cursor from next_cursor or prev_cursor, send it empty for the first page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the total, defaults to true with offset paging and false with a cursor",
+                        "name": "count",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get All Event",
                 "responses": {
                     "200": {
                         "description": "OK"
                     }
                 }
-            },
+            }
+        },
+        "/v1/outbox/retry-dead": {
             "post": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Create Event",
+                "description": "Queue every dead-lettered message again",
                 "consumes": [
                     "application/json"
                 ],
@@ -1254,20 +1118,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Event"
-                ],
-                "summary": "Create Event",
-                "parameters": [
-                    {
-                        "description": "Create Event Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.EventRequest"
-                        }
-                    }
+                    "Outbox"
                 ],
+                "summary": "Retry Dead Outbox Messages",
                 "responses": {
                     "200": {
                         "description": "OK"
@@ -1275,9 +1128,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/event/{id}": {
+        "/v1/outbox/{id}": {
             "get": {
-                "description": "Get Event By ID",
+                "security": [
+                    {
+                        "JwtToken": []
+                    }
+                ],
+                "description": "Get Outbox Message By ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1285,13 +1143,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Event"
+                    "Outbox"
                 ],
-                "summary": "Get Event By ID",
+                "summary": "Get Outbox Message By ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Event ID",
+                        "description": "Outbox Message ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1302,14 +1160,16 @@ const docTemplate = `{
                         "description": "OK"
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/v1/outbox/{id}/retry": {
+            "post": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Update Event",
+                "description": "Queue a dead or pending message again with a fresh attempt budget",
                 "consumes": [
                     "application/json"
                 ],
@@ -1317,25 +1177,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Event"
+                    "Outbox"
                 ],
-                "summary": "Update Event",
+                "summary": "Retry Outbox Message",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Event ID",
+                        "description": "Outbox Message ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update Event Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.EventRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -1343,14 +1194,16 @@ const docTemplate = `{
                         "description": "OK"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/permission/all": {
+            "get": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Delete Event",
+                "description": "Get All Permissions",
                 "consumes": [
                     "application/json"
                 ],
@@ -1358,18 +1211,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Event"
-                ],
-                "summary": "Delete Event",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Event ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Role"
                 ],
+                "summary": "Get All Permissions",
                 "responses": {
                     "200": {
                         "description": "OK"
@@ -1377,9 +1221,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/faq": {
+        "/v1/region/city/{prov_id}": {
             "get": {
-                "description": "Get Faqs",
+                "description": "List the cities of a province, or the ones matching search",
                 "consumes": [
                     "application/json"
                 ],
@@ -1387,45 +1231,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "FAQ"
+                    "Region"
                 ],
-                "summary": "Get Faqs",
+                "summary": "Get Cities",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Province ID",
+                        "name": "prov_id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Search",
+                        "description": "Name prefix, typos are tolerated",
                         "name": "search",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Order",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1433,14 +1254,11 @@ const docTemplate = `{
                         "description": "OK"
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create Faq",
+            }
+        },
+        "/v1/region/postal-code/{code}": {
+            "get": {
+                "description": "List the cities using a postal code, with their province",
                 "consumes": [
                     "application/json"
                 ],
@@ -1448,18 +1266,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "FAQ"
+                    "Region"
                 ],
-                "summary": "Create Faq",
+                "summary": "Get Regions By Postal Code",
                 "parameters": [
                     {
-                        "description": "Create Faq Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.FaqRequest"
-                        }
+                        "type": "string",
+                        "description": "Postal code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1469,9 +1285,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/faq/{id}": {
+        "/v1/region/province": {
             "get": {
-                "description": "Get Faq By ID",
+                "description": "List every province, or the ones matching search",
                 "consumes": [
                     "application/json"
                 ],
@@ -1479,16 +1295,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "FAQ"
+                    "Region"
                 ],
-                "summary": "Get Faq By ID",
+                "summary": "Get Provinces",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Faq ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Name prefix, typos are tolerated",
+                        "name": "search",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1496,14 +1311,11 @@ const docTemplate = `{
                         "description": "OK"
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Faq",
+            }
+        },
+        "/v1/region/search": {
+            "get": {
+                "description": "Search provinces, cities and subdistricts by name. Prefixes match and small typos are tolerated; every result carries the names of its parent regions.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1511,25 +1323,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "FAQ"
+                    "Region"
                 ],
-                "summary": "Update Faq",
+                "summary": "Search Regions",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Faq ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Name to search, at least 2 characters",
+                        "name": "q",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Update Faq Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.FaqRequest"
-                        }
+                        "type": "string",
+                        "description": "Only search one level: province, city or subdistrict",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum results, default 20, at most 100",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1537,14 +1352,11 @@ const docTemplate = `{
                         "description": "OK"
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete Faq",
+            }
+        },
+        "/v1/region/subdistrict/{city_id}": {
+            "get": {
+                "description": "List the subdistricts of a city, or the ones matching search",
                 "consumes": [
                     "application/json"
                 ],
@@ -1552,16 +1364,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "FAQ"
+                    "Region"
                 ],
-                "summary": "Delete Faq",
+                "summary": "Get Subdistricts",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Faq ID",
-                        "name": "id",
+                        "description": "City ID",
+                        "name": "city_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name prefix, typos are tolerated",
+                        "name": "search",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1571,65 +1389,106 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/file/upload": {
-            "post": {
+        "/v1/role": {
+            "get": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Upload File",
+                "description": "Get Roles",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "File"
+                    "Role"
                 ],
-                "summary": "Upload File",
+                "summary": "Get Roles",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "File to upload (PDF, JPEG, JPG, PNG)",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    }
-                ],
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter, e.g. filter[is_default]=true",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset cursor from next_cursor or prev_cursor, send it empty for the first page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the total, defaults to true with offset paging and false with a cursor",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK"
                     }
                 }
-            }
-        },
-        "/v1/file/upload-multiple": {
+            },
             "post": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Upload Multiple Files",
+                "description": "Create Role",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "File"
+                    "Role"
                 ],
-                "summary": "Upload Multiple Files",
+                "summary": "Create Role",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Files to upload (PDF, JPEG, JPG, PNG)",
-                        "name": "files",
-                        "in": "formData",
-                        "required": true
+                        "description": "Create Role Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqres.RoleRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1639,37 +1498,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/host": {
+        "/v1/role/all": {
             "get": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Hosts",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Host"
-                ],
-                "summary": "Get Hosts",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create Host",
+                "description": "Get All Roles",
                 "consumes": [
                     "application/json"
                 ],
@@ -1677,20 +1513,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Host"
-                ],
-                "summary": "Create Host",
-                "parameters": [
-                    {
-                        "description": "Create Host Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.HostRequest"
-                        }
-                    }
+                    "Role"
                 ],
+                "summary": "Get All Roles",
                 "responses": {
                     "200": {
                         "description": "OK"
@@ -1698,14 +1523,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/host/{id}": {
+        "/v1/role/{id}": {
             "get": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Host By ID",
+                "description": "Get Role By ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1713,13 +1538,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Host"
+                    "Role"
                 ],
-                "summary": "Get Host By ID",
+                "summary": "Get Role By ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Host ID",
+                        "description": "Role ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1737,7 +1562,7 @@ const docTemplate = `{
                         "JwtToken": []
                     }
                 ],
-                "description": "Update Host",
+                "description": "Update Role. Permissions are replaced when the list is sent.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1745,24 +1570,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Host"
+                    "Role"
                 ],
-                "summary": "Update Host",
+                "summary": "Update Role",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Host ID",
+                        "description": "Role ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update Host Request",
+                        "description": "Update Role Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqres.HostRequest"
+                            "$ref": "#/definitions/reqres.RoleRequest"
                         }
                     }
                 ],
@@ -1778,7 +1603,7 @@ const docTemplate = `{
                         "JwtToken": []
                     }
                 ],
-                "description": "Delete Host",
+                "description": "Delete Role. Roles that still have users cannot be deleted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1786,13 +1611,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Host"
+                    "Role"
                 ],
-                "summary": "Delete Host",
+                "summary": "Delete Role",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Host ID",
+                        "description": "Role ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1805,14 +1630,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/log": {
+        "/v1/user": {
             "get": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Logs",
+                "description": "Get Users",
                 "consumes": [
                     "application/json"
                 ],
@@ -1820,14 +1645,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Log"
+                    "User"
                 ],
-                "summary": "Get Logs",
+                "summary": "Get Users",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "role_id",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Search",
-                        "name": "search",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
                         "in": "query"
                     },
                     {
@@ -1836,6 +1673,12 @@ const docTemplate = `{
                         "name": "limit",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Search",
+                        "name": "search",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Sort",
@@ -1849,9 +1692,33 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
+                        "type": "string",
+                        "description": "Filter, e.g. filter[status]=1, filter[created_at][gte]=2025-01-01, filter[role_id][in]=1,2",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset cursor from next_cursor or prev_cursor, send it empty for the first page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the total, defaults to true with offset paging and false with a cursor",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to return, e.g. id,name,email",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated relations to load: role, province, city, subdistrict",
+                        "name": "expand",
                         "in": "query"
                     }
                 ],
@@ -1860,16 +1727,14 @@ const docTemplate = `{
                         "description": "OK"
                     }
                 }
-            }
-        },
-        "/v1/log/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Get Log By ID",
+                "description": "Create User. Without role_id the user gets the default role. Setting role_id needs the user.assign_role permission and only roles granting no more than your own.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1877,16 +1742,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Log"
+                    "User"
                 ],
-                "summary": "Get Log By ID",
+                "summary": "Create User",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Create User Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqres.UserRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1894,14 +1761,16 @@ const docTemplate = `{
                         "description": "OK"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/user/all": {
+            "get": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Delete Log By ID",
+                "description": "Get All Users",
                 "consumes": [
                     "application/json"
                 ],
@@ -1909,18 +1778,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Log"
-                ],
-                "summary": "Delete Log By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "User"
                 ],
+                "summary": "Get All Users",
                 "responses": {
                     "200": {
                         "description": "OK"
@@ -1928,40 +1788,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/lp/banner": {
+        "/v1/user/{id}": {
             "get": {
-                "description": "Get All Lp Banner",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "LpBanner"
-                ],
-                "summary": "Get All Lp Banner",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Flag",
-                        "name": "flag",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Create Lp Banner",
+                "description": "Get User By ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1969,18 +1803,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "LpBanner"
+                    "User"
                 ],
-                "summary": "Create Lp Banner",
+                "summary": "Get User By ID",
                 "parameters": [
                     {
-                        "description": "Create Lp Banner Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.LpBannerRequest"
-                        }
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to return, e.g. id,name,email",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated relations to load: role, province, city, subdistrict",
+                        "name": "expand",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1988,16 +1832,14 @@ const docTemplate = `{
                         "description": "OK"
                     }
                 }
-            }
-        },
-        "/v1/lp/banner/{id}": {
+            },
             "put": {
                 "security": [
                     {
                         "JwtToken": []
                     }
                 ],
-                "description": "Update Lp Banner",
+                "description": "Update User. Users whose role grants permissions you do not have cannot be changed, and changing the email marks the user unverified. Sending any of prov, kab and kec replaces the whole address, which must follow the province, city, subdistrict hierarchy. Changing role_id needs the user.assign_role permission, and neither the current nor the new role may grant more than your own.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2005,24 +1847,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "LpBanner"
+                    "User"
                 ],
-                "summary": "Update Lp Banner",
+                "summary": "Update User",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Lp Banner ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update Lp Banner Request",
+                        "description": "Update User Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqres.LpBannerRequest"
+                            "$ref": "#/definitions/reqres.UserUpdateRequest"
                         }
                     }
                 ],
@@ -2038,7 +1880,7 @@ const docTemplate = `{
                         "JwtToken": []
                     }
                 ],
-                "description": "Delete Lp Banner",
+                "description": "Delete User. Users whose role grants permissions you do not have cannot be deleted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2046,13 +1888,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "LpBanner"
+                    "User"
                 ],
-                "summary": "Delete Lp Banner",
+                "summary": "Delete User",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Lp Banner ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2064,4891 +1906,144 @@ const docTemplate = `{
                     }
                 }
             }
+        }
+    },
+    "definitions": {
+        "reqres.ChangeEmailRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
         },
-        "/v1/lp/best-seller": {
-            "get": {
-                "description": "Get All Lp Best Seller",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "LpBestSeller"
-                ],
-                "summary": "Get All Lp Best Seller",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create Lp Best Seller",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "LpBestSeller"
-                ],
-                "summary": "Create Lp Best Seller",
-                "parameters": [
-                    {
-                        "description": "Create Lp Best Seller Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.LpBestSellerRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/lp/best-seller/{id}": {
-            "get": {
-                "description": "Get Lp Best Seller By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "LpBestSeller"
-                ],
-                "summary": "Get Lp Best Seller By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Lp Best Seller ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Lp Best Seller",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "LpBestSeller"
-                ],
-                "summary": "Update Lp Best Seller",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Lp Best Seller ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Lp Best Seller Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.LpBestSellerRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete Lp Best Seller",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "LpBestSeller"
-                ],
-                "summary": "Delete Lp Best Seller",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Lp Best Seller ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/lp/sale-banner": {
-            "get": {
-                "description": "Get All Lp Sale Banner",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "LpSaleBanner"
-                ],
-                "summary": "Get All Lp Sale Banner",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create Lp Sale Banner",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "LpSaleBanner"
-                ],
-                "summary": "Create Lp Sale Banner",
-                "parameters": [
-                    {
-                        "description": "Create Lp Sale Banner Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.LpSaleBannerRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/lp/sale-banner/{id}": {
-            "get": {
-                "description": "Get Lp Sale Banner By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "LpSaleBanner"
-                ],
-                "summary": "Get Lp Sale Banner By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Lp Sale Banner ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Lp Sale Banner",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "LpSaleBanner"
-                ],
-                "summary": "Update Lp Sale Banner",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Lp Sale Banner ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Lp Sale Banner Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.LpSaleBannerRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete Lp Sale Banner",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "LpSaleBanner"
-                ],
-                "summary": "Delete Lp Sale Banner",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Lp Sale Banner ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/lp/testimoni": {
-            "get": {
-                "description": "Get All Lp Testimoni",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "LpTestimoni"
-                ],
-                "summary": "Get All Lp Testimoni",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create Lp Testimoni",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "LpTestimoni"
-                ],
-                "summary": "Create Lp Testimoni",
-                "parameters": [
-                    {
-                        "description": "Create Lp Testimoni Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.LpTestimoniRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/lp/testimoni/{id}": {
-            "get": {
-                "description": "Get Lp Testimoni By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "LpTestimoni"
-                ],
-                "summary": "Get Lp Testimoni By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Lp Testimoni ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Lp Testimoni",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "LpTestimoni"
-                ],
-                "summary": "Update Lp Testimoni",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Lp Testimoni ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Lp Testimoni Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.LpTestimoniRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete Lp Testimoni",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "LpTestimoni"
-                ],
-                "summary": "Delete Lp Testimoni",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Lp Testimoni ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/midtrans": {
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Midtrans Payment",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Midtrans"
-                ],
-                "summary": "Midtrans Payment",
-                "parameters": [
-                    {
-                        "description": "Midtrans Payment Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.MidtransPaymentRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/midtrans/checkstatuspayment": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Check Payment Method",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Midtrans"
-                ],
-                "summary": "Check Payment Method",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Invoice ID",
-                        "name": "invoice_id",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/notification/user-helper": {
-            "post": {
-                "description": "Send Email User Helper",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Notification"
-                ],
-                "summary": "Send Email User Helper",
-                "parameters": [
-                    {
-                        "description": "Send Email User Helper Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.UserEmailHelperNotification"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/order": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Orders",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Order"
-                ],
-                "summary": "Get Orders",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Resi",
-                        "name": "resi",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Created At Margin Top",
-                        "name": "created_at_margin_top",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Created At Margin Bottom",
-                        "name": "created_at_margin_bottom",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search by invoice_id",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Order",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create Order",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Order"
-                ],
-                "summary": "Create Order",
-                "parameters": [
-                    {
-                        "description": "Create Order Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.OrderRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/order/change-status/{id}": {
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Status Order",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Order"
-                ],
-                "summary": "Update Status Order",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Status Order Request",
-                        "name": "req",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.ChangeStatusOrderRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/order/order-detail": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Order Detail By Order ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "OrderDetail"
-                ],
-                "summary": "Get Order Detail By Order ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Order ID",
-                        "name": "order_id",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/order/order-detail/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Order Detail By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "OrderDetail"
-                ],
-                "summary": "Get Order Detail By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Order Detail ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Order Detail",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "OrderDetail"
-                ],
-                "summary": "Update Order Detail",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Order Detail ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Order Detail Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.OrderDetailRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete Order Detail",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "OrderDetail"
-                ],
-                "summary": "Delete Order Detail",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Order Detail ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/order/user": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Order By User ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Order"
-                ],
-                "summary": "Get Order By User ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Resi",
-                        "name": "resi",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Created At Margin Top",
-                        "name": "created_at_margin_top",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Created At Margin Bottom",
-                        "name": "created_at_margin_bottom",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search by invoice_id",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Order",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/order/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Order By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Order"
-                ],
-                "summary": "Get Order By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Order",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Order"
-                ],
-                "summary": "Update Order",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Order Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.OrderRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete Order",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Order"
-                ],
-                "summary": "Delete Order",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/privacy-policy": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Privacy Policy",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "PrivacyPolicy"
-                ],
-                "summary": "Get Privacy Policy",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "search By Title",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "limit default 10",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "offset",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "sort ASC or DESC",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "order by id",
-                        "name": "order",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create Privacy Policy",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "PrivacyPolicy"
-                ],
-                "summary": "Create Privacy Policy",
-                "parameters": [
-                    {
-                        "description": "Create Privacy Policy Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.PrivacyPolicyRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/privacy-policy/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Privacy Policy By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "PrivacyPolicy"
-                ],
-                "summary": "Get Privacy Policy By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Privacy Policy ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Privacy Policy",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "PrivacyPolicy"
-                ],
-                "summary": "Update Privacy Policy",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Privacy Policy ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Privacy Policy Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.PrivacyPolicyRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete Privacy Policy",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "PrivacyPolicy"
-                ],
-                "summary": "Delete Privacy Policy",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Privacy Policy ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product": {
-            "get": {
-                "description": "Get Products",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Product"
-                ],
-                "summary": "Get Products",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "kategori_id",
-                        "name": "kategori_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "is_sale true or false",
-                        "name": "is_sale",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "is_ready true or false",
-                        "name": "is_ready",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "price_max",
-                        "name": "price_max",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "price_min",
-                        "name": "price_min",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "terlaris true or false",
-                        "name": "terlaris",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "terbaru true or false",
-                        "name": "terbaru",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "tertinggi true or false",
-                        "name": "tertinggi",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "status 0 = inactive, 1 = active",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "search",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "limit default 10",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "offset 0 = first page, 10 = second page, 20 = third page, etc",
-                        "name": "offset",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create Product",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Product"
-                ],
-                "summary": "Create Product",
-                "parameters": [
-                    {
-                        "description": "Create Product Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.ProductRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product-package": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Product Packages",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductPackage"
-                ],
-                "summary": "Get Product Packages",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "search product package by name",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "limit default 10",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "offset",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "status 0 = inactive, 1 = active",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "sort ASC or DESC",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "order by id",
-                        "name": "order",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create Product Package",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductPackage"
-                ],
-                "summary": "Create Product Package",
-                "parameters": [
-                    {
-                        "description": "Create Product Package Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.ProductPackageRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product-package/detail": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Product Package Details",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductPackageDetail"
-                ],
-                "summary": "Get Product Package Details",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product Package ID",
-                        "name": "package_id",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create Product Package Detail",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductPackageDetail"
-                ],
-                "summary": "Create Product Package Detail",
-                "parameters": [
-                    {
-                        "description": "Create Product Package Detail Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.ProductPackageDetailCreateRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product-package/detail/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Product Package Detail By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductPackageDetail"
-                ],
-                "summary": "Get Product Package Detail By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product Package Detail ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Product Package Detail",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductPackageDetail"
-                ],
-                "summary": "Update Product Package Detail",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product Package Detail ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Product Package Detail Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.ProductPackageDetailRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete Product Package Detail",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductPackageDetail"
-                ],
-                "summary": "Delete Product Package Detail",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product Package Detail ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product-package/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Product Package By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductPackage"
-                ],
-                "summary": "Get Product Package By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product Package ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Product Package",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductPackage"
-                ],
-                "summary": "Update Product Package",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product Package ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Product Package Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.ProductPackageRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete Product Package",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductPackage"
-                ],
-                "summary": "Delete Product Package",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product Package ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product/all": {
-            "get": {
-                "description": "Get All Products",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Product"
-                ],
-                "summary": "Get All Products",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product/cart": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get cart details",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductCart"
-                ],
-                "summary": "Get cart details",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "product_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Package ID",
-                        "name": "package_id",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create cart detail",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductCart"
-                ],
-                "summary": "Create cart detail",
-                "parameters": [
-                    {
-                        "description": "Create Cart Detail Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.CartDetailRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product/cart/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get cart detail by id",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductCart"
-                ],
-                "summary": "Get cart detail by id",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Cart Detail ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update cart detail",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductCart"
-                ],
-                "summary": "Update cart detail",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Cart Detail ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Cart Detail Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.CartDetailRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete cart detail",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductCart"
-                ],
-                "summary": "Delete cart detail",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Cart Detail ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product/category": {
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create Category",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Category"
-                ],
-                "summary": "Create Category",
-                "parameters": [
-                    {
-                        "description": "Create Category Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.CategoryRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product/category/all": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get All Categories",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Category"
-                ],
-                "summary": "Get All Categories",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product/category/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Category By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Category"
-                ],
-                "summary": "Get Category By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Category",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Category"
-                ],
-                "summary": "Update Category",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Category Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.CategoryRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete Category",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Category"
-                ],
-                "summary": "Delete Category",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product/recomendation": {
-            "get": {
-                "description": "Get Product Recomendation",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Product"
-                ],
-                "summary": "Get Product Recomendation",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product/size": {
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create Product Size",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductSize"
-                ],
-                "summary": "Create Product Size",
-                "parameters": [
-                    {
-                        "description": "Create Product Size Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.ProductSizeRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product/size/{id}": {
-            "get": {
-                "description": "Get Product Size By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductSize"
-                ],
-                "summary": "Get Product Size By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product Size ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Product Size",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductSize"
-                ],
-                "summary": "Update Product Size",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product Size ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Product Size Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.ProductSizeRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete Product Size",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductSize"
-                ],
-                "summary": "Delete Product Size",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product Size ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product/size/{product_id}": {
-            "get": {
-                "description": "Get Product Size By Product ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProductSize"
-                ],
-                "summary": "Get Product Size By Product ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "product_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product/testimoni": {
-            "get": {
-                "description": "Get Testimoni Products",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "TestimoniProduct"
-                ],
-                "summary": "Get Testimoni Products",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "product_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Rating",
-                        "name": "rating",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Is Image",
-                        "name": "is_image",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Order",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Status 0 = Inactive, 1 = Active",
-                        "name": "status",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create Testimoni Product",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "TestimoniProduct"
-                ],
-                "summary": "Create Testimoni Product",
-                "parameters": [
-                    {
-                        "description": "Create Testimoni Product Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.TestimoniProductRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product/testimoni/like": {
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "LikeUnlikeTestimoniProduct",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "TestimoniProductLike"
-                ],
-                "summary": "LikeUnlikeTestimoniProduct",
-                "parameters": [
-                    {
-                        "description": "LikeUnlikeTestimoniProduct Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.LikeRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product/testimoni/status/{id}": {
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Status Testimoni Product",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "TestimoniProduct"
-                ],
-                "summary": "Update Status Testimoni Product",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Testimoni Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Status Testimoni Product Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.TestimoniProductUpdateStatusRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product/testimoni/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Testimoni Product By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "TestimoniProduct"
-                ],
-                "summary": "Get Testimoni Product By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Testimoni Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Testimoni Product",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "TestimoniProduct"
-                ],
-                "summary": "Update Testimoni Product",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Testimoni Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Testimoni Product Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.TestimoniProductRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete Testimoni Product",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "TestimoniProduct"
-                ],
-                "summary": "Delete Testimoni Product",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Testimoni Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/product/{id}": {
-            "get": {
-                "description": "Get Product By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Product"
-                ],
-                "summary": "Get Product By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Product",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Product"
-                ],
-                "summary": "Update Product",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Product Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.ProductUpdateRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete Product",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Product"
-                ],
-                "summary": "Delete Product",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/region/city": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get all cities",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Region"
-                ],
-                "summary": "Get all cities",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/region/city/{prov_id}": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get all cities",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Region"
-                ],
-                "summary": "Get all cities",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Province ID",
-                        "name": "prov_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/region/province": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get all provinces",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Region"
-                ],
-                "summary": "Get all provinces",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/region/subdistrict": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get all subdistricts",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Region"
-                ],
-                "summary": "Get all subdistricts",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/region/subdistrict/{city_id}": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get all subdistricts",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Region"
-                ],
-                "summary": "Get all subdistricts",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "City ID",
-                        "name": "city_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/role/all": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get All Roles",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Role"
-                ],
-                "summary": "Get All Roles",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/role/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Role By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Role"
-                ],
-                "summary": "Get Role By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Role ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/shipping/cancel-order": {
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Ican Deliv Cancel Order",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Shipping"
-                ],
-                "summary": "Ican Deliv Cancel Order",
-                "parameters": [
-                    {
-                        "description": "Request body",
-                        "name": "req",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.IcanDelivAWBRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/shipping/category": {
-            "get": {
-                "description": "Ican Deliv Get Category",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Shipping"
-                ],
-                "summary": "Ican Deliv Get Category",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/shipping/cek-tarif": {
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Ican Deliv Cek Tarif",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Shipping"
-                ],
-                "summary": "Ican Deliv Cek Tarif",
-                "parameters": [
-                    {
-                        "description": "Request body",
-                        "name": "req",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.IcanDelivDataCheckTarifRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/shipping/create-order": {
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Ican Deliv Create Order",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Shipping"
-                ],
-                "summary": "Ican Deliv Create Order",
-                "parameters": [
-                    {
-                        "description": "Request body",
-                        "name": "req",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.IcanDelivCreateOrderRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/shipping/deposit": {
-            "get": {
-                "description": "Ican Deliv Get Deposit",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Shipping"
-                ],
-                "summary": "Ican Deliv Get Deposit",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/shipping/icanlogin": {
-            "get": {
-                "description": "Ican Deliv Test Login",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Shipping"
-                ],
-                "summary": "Ican Deliv Test Login",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/shipping/shipping-detail": {
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Ican Deliv Get Shipping Detail",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Shipping"
-                ],
-                "summary": "Ican Deliv Get Shipping Detail",
-                "parameters": [
-                    {
-                        "description": "Request body",
-                        "name": "req",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.IcanDelivAWBRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/shipping/tracking": {
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Ican Deliv Get Tracking",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Shipping"
-                ],
-                "summary": "Ican Deliv Get Tracking",
-                "parameters": [
-                    {
-                        "description": "Request body",
-                        "name": "req",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.IcanDelivAWBRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/size": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Sizes",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Size"
-                ],
-                "summary": "Get Sizes",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Search",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Order",
-                        "name": "order",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create Size",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Size"
-                ],
-                "summary": "Create Size",
-                "parameters": [
-                    {
-                        "description": "Create Size Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.SizeRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/size/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Size By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Size"
-                ],
-                "summary": "Get Size By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Size ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Size",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Size"
-                ],
-                "summary": "Update Size",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Size ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Size Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.SizeRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete Size",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Size"
-                ],
-                "summary": "Delete Size",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Size ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/syarat-ketentuan": {
-            "get": {
-                "description": "Get Syarat Ketentuan",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "SyaratKetentuan"
-                ],
-                "summary": "Get Syarat Ketentuan",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "search By Title",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "limit default 10",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "offset",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "sort ASC or DESC",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "order by id",
-                        "name": "order",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create Syarat Ketentuan",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "SyaratKetentuan"
-                ],
-                "summary": "Create Syarat Ketentuan",
-                "parameters": [
-                    {
-                        "description": "Create Syarat Ketentuan Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.SyaratKetentuanRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/syarat-ketentuan/{id}": {
-            "get": {
-                "description": "Get Syarat Ketentuan By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "SyaratKetentuan"
-                ],
-                "summary": "Get Syarat Ketentuan By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Syarat Ketentuan ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Syarat Ketentuan",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "SyaratKetentuan"
-                ],
-                "summary": "Update Syarat Ketentuan",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Syarat Ketentuan ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Syarat Ketentuan Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.SyaratKetentuanRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete Syarat Ketentuan",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "SyaratKetentuan"
-                ],
-                "summary": "Delete Syarat Ketentuan",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Syarat Ketentuan ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/user": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Users",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
-                ],
-                "summary": "Get Users",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Role ID",
-                        "name": "role_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Order",
-                        "name": "order",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create User",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
-                ],
-                "summary": "Create User",
-                "parameters": [
-                    {
-                        "description": "Create User Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.UserRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/user/all": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get All Users",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
-                ],
-                "summary": "Get All Users",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/user/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get User By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
-                ],
-                "summary": "Get User By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update User",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
-                ],
-                "summary": "Update User",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update User Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.UserUpdateRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete User",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
-                ],
-                "summary": "Delete User",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/voucher": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get All Vouchers",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Voucher"
-                ],
-                "summary": "Get All Vouchers",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Create Voucher",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Voucher"
-                ],
-                "summary": "Create Voucher",
-                "parameters": [
-                    {
-                        "description": "Create Voucher Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.VoucherRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/voucher/code": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Voucher By Code",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Voucher"
-                ],
-                "summary": "Get Voucher By Code",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Voucher Code",
-                        "name": "code",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/voucher/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Get Voucher By ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Voucher"
-                ],
-                "summary": "Get Voucher By ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Voucher ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Update Voucher",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Voucher"
-                ],
-                "summary": "Update Voucher",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Voucher ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Voucher Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqres.VoucherRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JwtToken": []
-                    }
-                ],
-                "description": "Delete Voucher",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Voucher"
-                ],
-                "summary": "Delete Voucher",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Voucher ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "reqres.AboutMeRequest": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "image": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
-                },
-                "type": {
-                    "type": "integer"
-                }
-            }
-        },
-        "reqres.CartDetailRequest": {
-            "type": "object",
-            "properties": {
-                "package_id": {
-                    "type": "integer"
-                },
-                "product_id": {
-                    "type": "integer"
-                },
-                "quantity": {
-                    "type": "integer"
-                },
-                "quantity_package": {
-                    "type": "integer"
-                },
-                "size_id": {
-                    "type": "integer"
-                },
-                "user_id": {
-                    "description": "CartID    int     ` + "`" + `json:\"cart_id\"` + "`" + `",
-                    "type": "integer"
-                }
-            }
-        },
-        "reqres.CartRequest": {
-            "type": "object",
-            "properties": {
-                "user_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "reqres.CategoryRequest": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "integer"
-                }
-            }
-        },
-        "reqres.ChangePassword": {
-            "type": "object",
-            "properties": {
-                "new_password": {
-                    "type": "string"
-                },
-                "new_password_confirm": {
-                    "type": "string"
-                }
-            }
-        },
-        "reqres.ChangeStatusOrderRequest": {
-            "type": "object",
-            "properties": {
-                "status": {
-                    "type": "integer"
-                }
-            }
-        },
-        "reqres.CustomerDetails": {
-            "type": "object",
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "first_name": {
-                    "type": "string"
-                },
-                "last_name": {
-                    "type": "string"
-                },
-                "phone": {
-                    "type": "string"
-                }
-            }
-        },
-        "reqres.EventRequest": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
-                }
-            }
-        },
-        "reqres.FaqRequest": {
-            "type": "object",
-            "properties": {
-                "answer": {
-                    "type": "string"
-                },
-                "question": {
-                    "type": "string"
-                }
-            }
-        },
-        "reqres.ForgotPasswordRequest": {
-            "type": "object",
-            "properties": {
-                "email": {
-                    "type": "string"
-                }
-            }
-        },
-        "reqres.HostRequest": {
-            "type": "object",
-            "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "city_id": {
-                    "type": "integer"
-                },
-                "kelurahan": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "phone": {
-                    "type": "string"
-                },
-                "postal_code": {
-                    "type": "string"
-                },
-                "province_id": {
-                    "type": "integer"
-                },
-                "subdistrict_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "reqres.IcanDelivAWBRequest": {
-            "type": "object",
-            "properties": {
-                "awb": {
-                    "type": "string"
-                }
-            }
-        },
-        "reqres.IcanDelivCreateOrderRequest": {
-            "type": "object",
-            "properties": {
-                "address_penerima": {
-                    "type": "string"
-                },
-                "asuransi": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "catatan": {
-                    "type": "string"
-                },
-                "city_penerima": {
-                    "type": "integer"
-                },
-                "cod_switch": {
-                    "type": "string"
-                },
-                "date_picker": {
-                    "description": "2024-07-17",
-                    "type": "string"
-                },
-                "dropoff_switch": {
-                    "type": "string"
-                },
-                "harga_barang": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "invoice_id": {
-                    "type": "string"
-                },
-                "kategori_barang": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "kategori_berat": {
-                    "description": "dalam kg",
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "kategori_jumlah": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "kategori_lebar": {
-                    "description": "dalam cm",
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "kategori_panjang": {
-                    "description": "dalam cm",
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "kategori_tinggi": {
-                    "description": "dalam cm",
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "kelurahan_penerima": {
-                    "type": "string"
-                },
-                "nama_barang": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "nama_penerima": {
-                    "type": "string"
-                },
-                "phone_number_penerima": {
-                    "type": "string"
-                },
-                "post_code_penerima": {
-                    "type": "string"
-                },
-                "province_penerima": {
-                    "type": "integer"
-                },
-                "selected_pesanan_type": {
-                    "type": "string"
-                },
-                "selected_time": {
-                    "description": "pickup start 10am to 18pm",
-                    "type": "string"
-                },
-                "subdistrict_penerima": {
-                    "type": "integer"
-                },
-                "tariff_service": {
-                    "type": "integer"
-                }
-            }
-        },
-        "reqres.IcanDelivDataCheckTarifRequest": {
-            "type": "object",
-            "properties": {
-                "cargo": {
-                    "type": "string"
-                },
-                "cod": {
-                    "type": "string"
-                },
-                "harga_barang_asuransi": {
-                    "type": "integer"
-                },
-                "kategori_barang": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "product_qty": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "product_value": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "product_weight": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "subdistrict_penerima": {
-                    "type": "integer"
-                },
-                "timerange": {
-                    "type": "string"
-                },
-                "total_harga_barang": {
-                    "type": "integer"
-                }
-            }
-        },
-        "reqres.ItemDetail": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "price": {
-                    "type": "integer"
-                },
-                "quantity": {
-                    "type": "integer"
-                },
-                "url": {
-                    "type": "string"
-                }
-            }
-        },
-        "reqres.LikeRequest": {
-            "type": "object",
-            "properties": {
-                "testimoni_product_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "reqres.LoginRequest": {
-            "type": "object",
-            "properties": {
-                "emailorphone": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string"
-                }
-            }
-        },
-        "reqres.LpBannerRequest": {
-            "type": "object",
-            "properties": {
-                "flag": {
-                    "type": "integer"
-                },
-                "image": {
-                    "type": "string"
-                },
-                "link": {
-                    "type": "string"
-                }
-            }
-        },
-        "reqres.LpBestSellerRequest": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
-                }
-            }
-        },
-        "reqres.LpSaleBannerRequest": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "link": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
-                }
-            }
-        },
-        "reqres.LpTestimoniRequest": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "image": {
-                    "type": "string"
-                },
-                "image_profile": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "rating": {
-                    "type": "integer"
-                },
-                "title": {
-                    "type": "string"
-                }
-            }
-        },
-        "reqres.MidtransPaymentRequest": {
-            "type": "object",
-            "properties": {
-                "customer_details": {
-                    "$ref": "#/definitions/reqres.CustomerDetails"
-                },
-                "item_details": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/reqres.ItemDetail"
-                    }
-                },
-                "transaction_details": {
-                    "$ref": "#/definitions/reqres.TransactionDetails"
-                }
-            }
-        },
-        "reqres.OrderDetailRequest": {
-            "type": "object",
-            "properties": {
-                "order_id": {
-                    "type": "integer"
-                },
-                "package_id": {
-                    "type": "integer"
-                },
-                "price": {
-                    "type": "number"
-                },
-                "product_id": {
-                    "type": "integer"
-                },
-                "quantity": {
-                    "type": "integer"
-                },
-                "quantity_package": {
-                    "type": "integer"
-                },
-                "size_id": {
-                    "type": "integer"
-                },
-                "user_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "reqres.OrderRequest": {
-            "type": "object",
-            "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "city": {
-                    "type": "integer"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "kelurahan": {
-                    "type": "string"
-                },
-                "phone": {
-                    "type": "string"
-                },
-                "postal_code": {
-                    "type": "string"
-                },
-                "products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/reqres.OrderDetailRequest"
-                    }
-                },
-                "province": {
-                    "type": "integer"
-                },
-                "shipping_id": {
-                    "type": "integer"
-                },
-                "shipping_price": {
-                    "type": "number"
-                },
-                "shipping_type": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "integer"
-                },
-                "subdist": {
-                    "type": "integer"
-                },
-                "total_price": {
-                    "type": "number"
-                },
-                "username": {
-                    "type": "string"
-                },
-                "voucher_id": {
-                    "type": "integer"
-                },
-                "voucher_price": {
-                    "type": "number"
-                },
-                "weight": {
-                    "type": "integer"
-                }
-            }
-        },
-        "reqres.PrivacyPolicyRequest": {
+        "reqres.ChangePassword": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "new_password": {
+                    "type": "string"
                 },
-                "title": {
+                "new_password_confirm": {
                     "type": "string"
                 }
             }
         },
-        "reqres.ProductPackageDetailCreateRequest": {
+        "reqres.FileShareRequest": {
             "type": "object",
             "properties": {
-                "package_id": {
-                    "type": "integer"
-                },
-                "product_id": {
+                "expires_in": {
+                    "description": "ExpiresIn is how many seconds the link stays valid, STORAGE_URL_TTL by default",
                     "type": "integer"
                 },
-                "size_id": {
-                    "type": "integer"
+                "variant": {
+                    "type": "string"
                 }
             }
         },
-        "reqres.ProductPackageDetailRequest": {
+        "reqres.ForgotPasswordRequest": {
             "type": "object",
             "properties": {
-                "product_id": {
-                    "type": "integer"
-                },
-                "size_id": {
-                    "type": "integer"
+                "email": {
+                    "type": "string"
                 }
             }
         },
-        "reqres.ProductPackageRequest": {
+        "reqres.LoginRequest": {
             "type": "object",
             "properties": {
-                "description": {
+                "emailorphone": {
                     "type": "string"
                 },
-                "name": {
+                "password": {
                     "type": "string"
-                },
-                "price": {
-                    "type": "number"
-                },
-                "products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/reqres.ProductPackageDetailRequest"
-                    }
-                },
-                "status": {
-                    "type": "integer"
                 }
             }
         },
-        "reqres.ProductRequest": {
+        "reqres.MeUpdateRequest": {
             "type": "object",
             "properties": {
-                "category_id": {
-                    "type": "integer"
-                },
-                "description": {
+                "address": {
                     "type": "string"
                 },
-                "dimensi": {
+                "gender": {
                     "type": "string"
                 },
-                "discount_percent": {
-                    "type": "number"
-                },
-                "event_id": {
+                "kab": {
                     "type": "integer"
                 },
-                "image": {
-                    "type": "string"
-                },
-                "is_best": {
-                    "type": "boolean"
-                },
-                "lebar": {
+                "kec": {
                     "type": "integer"
                 },
-                "list_image": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "material": {
+                "kel": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "package_id": {
-                    "type": "integer"
-                },
-                "panjang": {
-                    "type": "integer"
-                },
-                "price": {
-                    "type": "integer"
-                },
-                "selling_price": {
-                    "type": "integer"
-                },
-                "size": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/reqres.ProductSizeRequest"
-                    }
-                },
-                "size_type": {
+                "phone": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "integer"
-                },
-                "stock": {
-                    "type": "integer"
-                },
-                "superiority": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "postal_code": {
+                    "type": "string"
                 },
-                "tinggi": {
+                "prov": {
                     "type": "integer"
                 },
-                "warranty": {
+                "tgl_lahir": {
                     "type": "string"
-                },
-                "weight": {
-                    "type": "integer"
                 }
             }
         },
-        "reqres.ProductSizeRequest": {
+        "reqres.RefreshTokenRequest": {
             "type": "object",
             "properties": {
-                "price": {
-                    "type": "number"
-                },
-                "product_id": {
-                    "type": "integer"
-                },
-                "size_id": {
-                    "type": "integer"
-                },
-                "stock": {
-                    "type": "integer"
+                "refresh_token": {
+                    "type": "string"
                 }
             }
         },
-        "reqres.ProductSizeUpdateRequest": {
+        "reqres.ResendVerificationRequest": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
-                },
-                "price": {
-                    "type": "number"
-                },
-                "product_id": {
-                    "type": "integer"
-                },
-                "size_id": {
-                    "type": "integer"
-                },
-                "stock": {
-                    "type": "integer"
+                "email": {
+                    "type": "string"
                 }
             }
         },
-        "reqres.ProductUpdateRequest": {
+        "reqres.ResetPasswordRequest": {
             "type": "object",
             "properties": {
-                "category_id": {
-                    "type": "integer"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "dimensi": {
-                    "type": "string"
-                },
-                "discount_percent": {
-                    "type": "number"
-                },
-                "event_id": {
-                    "type": "integer"
-                },
-                "image": {
-                    "type": "string"
-                },
-                "is_best": {
-                    "type": "boolean"
-                },
-                "lebar": {
-                    "type": "integer"
-                },
-                "list_image": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "material": {
-                    "type": "string"
-                },
-                "name": {
+                "new_password": {
                     "type": "string"
                 },
-                "package_id": {
-                    "type": "integer"
-                },
-                "panjang": {
-                    "type": "integer"
-                },
-                "price": {
-                    "type": "integer"
-                },
-                "selling_price": {
-                    "type": "integer"
-                },
-                "size": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/reqres.ProductSizeUpdateRequest"
-                    }
-                },
-                "size_type": {
+                "new_password_confirm": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "integer"
-                },
-                "stock": {
-                    "type": "integer"
-                },
-                "superiority": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "tinggi": {
-                    "type": "integer"
-                },
-                "warranty": {
+                "token": {
                     "type": "string"
-                },
-                "weight": {
-                    "type": "integer"
                 }
             }
         },
-        "reqres.SizeRequest": {
+        "reqres.RoleRequest": {
             "type": "object",
             "properties": {
                 "description": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "reqres.SyaratKetentuanRequest": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "is_default": {
+                    "type": "boolean"
                 },
-                "title": {
-                    "type": "string"
-                }
-            }
-        },
-        "reqres.TestimoniProductRequest": {
-            "type": "object",
-            "properties": {
-                "description": {
+                "name": {
                     "type": "string"
                 },
-                "image": {
+                "permissions": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
-                },
-                "order_detail_id": {
-                    "type": "integer"
-                },
-                "product_id": {
-                    "type": "integer"
-                },
-                "rating": {
-                    "type": "number"
-                },
-                "user_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "reqres.TestimoniProductUpdateStatusRequest": {
-            "type": "object",
-            "properties": {
-                "status": {
-                    "type": "integer"
-                }
-            }
-        },
-        "reqres.TransactionDetails": {
-            "type": "object",
-            "properties": {
-                "gross_amount": {
-                    "type": "integer"
-                },
-                "order_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "reqres.UserEmailHelperNotification": {
-            "type": "object",
-            "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "fullname": {
-                    "type": "string"
-                },
-                "phone": {
-                    "type": "string"
-                },
-                "subject": {
-                    "type": "string"
                 }
             }
         },
@@ -7049,34 +2144,10 @@ const docTemplate = `{
                 }
             }
         },
-        "reqres.VoucherRequest": {
+        "reqres.VerifyEmailRequest": {
             "type": "object",
             "properties": {
-                "code_voucher": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "discount": {
-                    "type": "integer"
-                },
-                "max_price": {
-                    "type": "integer"
-                },
-                "min_price": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "nominal": {
-                    "type": "number"
-                },
-                "status": {
-                    "type": "integer"
-                },
-                "type": {
+                "token": {
                     "type": "string"
                 }
             }
@@ -7097,8 +2168,8 @@ var SwaggerInfo = &swag.Spec{
 	Host:             "",
 	BasePath:         "",
 	Schemes:          []string{},
-	Title:            "BASIC",
-	Description:      "API documentation by BASIC",
+	Title:            "PROJECT_NAME",
+	Description:      "API documentation by PROJECT_NAME",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
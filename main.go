@@ -1,9 +1,22 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"project-name/app/imaging"
+	"project-name/app/migrate"
+	"project-name/app/repository"
 	"project-name/app/router"
+	"project-name/app/scanner"
+	"project-name/app/seed"
+	"project-name/app/storage"
+	"project-name/app/worker"
 	"project-name/config"
+	"project-name/migrations"
 	"time"
 
 	_ "github.com/joho/godotenv/autoload"
@@ -21,12 +34,42 @@ import (
 // @name Authorization
 
 func main() {
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	app := echo.New()
 	config.Database()
 
-	// config.Redis()
+	if err := checkMigrations(); err != nil {
+		log.Fatal(err)
+	}
+
+	if config.LoadConfig().EnableDatabaseAutomigration {
+		if err := repository.SeedRolesAndPermissions(config.DB); err != nil {
+			log.Panic(err)
+		}
+	}
+
+	storage.Init()
+	imaging.Init()
+	scanner.Init()
+
+	if config.LoadConfig().SessionStore == "REDIS" {
+		config.Redis()
+	}
 	router.Init(app)
 
+	if config.LoadConfig().EnableOutboxWorker {
+		go worker.RunOutbox()
+	}
+	if config.LoadConfig().EnableFileSweeper {
+		go worker.RunFileSweeper()
+	}
+
 	// activateCron()
 
 	app.Server.Addr = "0.0.0.0:" + config.LoadConfig().Port
@@ -39,6 +82,56 @@ func main() {
 	graceful.ListenAndServe(app.Server, 5*time.Second)
 }
 
+// runCommand runs a command line subcommand instead of the server
+func runCommand(args []string) error {
+	switch args[0] {
+	case "migrate":
+		dir := filepath.Join(config.RootPath(), "migrations")
+		return migrate.Command(args[1:], migrations.FS, dir, config.Database, os.Stdout)
+	case "seed":
+		return seed.Command(args[1:], config.Database, seed.Options{
+			SQL:           os.DirFS(filepath.Join(config.RootPath(), "assets", "sql")),
+			AdminName:     config.LoadConfig().SeedAdminName,
+			AdminEmail:    config.LoadConfig().SeedAdminEmail,
+			AdminPassword: config.LoadConfig().SeedAdminPassword,
+		}, os.Stdout)
+	}
+
+	return errors.New("unknown command " + args[0] + ", available: migrate, seed")
+}
+
+// checkMigrations applies pending migrations when automigration is enabled, and
+// otherwise refuses to start while some are pending unless that is allowed
+func checkMigrations() error {
+	migrator, err := migrate.New(config.DB, migrations.FS)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	if config.LoadConfig().EnableDatabaseAutomigration {
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			log.Printf("Applied migration %s_%s", migration.Version, migration.Name)
+		}
+		return err
+	}
+
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		if config.LoadConfig().AllowPendingMigrations {
+			log.Printf("Warning: %d pending migrations", len(pending))
+			return nil
+		}
+		return fmt.Errorf("%d pending migrations, run `migrate up` or set ENABLE_DATABASE_AUTOMIGRATION", len(pending))
+	}
+
+	return nil
+}
+
 func activateCron() {
 	loc, _ := time.LoadLocation("Asia/Jakarta")
 	job := cron.New(cron.WithLocation(loc))
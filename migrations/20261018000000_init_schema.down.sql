@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS "subdistricts";
+DROP TABLE IF EXISTS "cities";
+DROP TABLE IF EXISTS "provinces";
+DROP TABLE IF EXISTS "outbox_messages";
+DROP TABLE IF EXISTS "email_verification_tokens";
+DROP TABLE IF EXISTS "password_reset_tokens";
+DROP TABLE IF EXISTS "role_permissions";
+DROP TABLE IF EXISTS "permissions";
+DROP TABLE IF EXISTS "roles";
+DROP TABLE IF EXISTS "sessions";
+DROP TABLE IF EXISTS "refresh_tokens";
+DROP TABLE IF EXISTS "users";
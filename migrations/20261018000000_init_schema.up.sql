@@ -0,0 +1,191 @@
+-- Baseline schema. Statements are idempotent so databases created by the old
+-- GORM AutoMigrate can adopt the migration history without changes.
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" varchar(255),
+    "email" varchar(255),
+    "password" varchar(255),
+    "gender" varchar(2),
+    "tgl_lahir" timestamp,
+    "phone" varchar(255),
+    "image" varchar(255),
+    "address" text,
+    "is_verify" bool,
+    "role_id" bigint,
+    "status" int8,
+    "prov" bigint,
+    "kab" bigint,
+    "kec" bigint,
+    "kel" varchar(255),
+    "postal_code" varchar(255),
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "refresh_tokens" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" int8,
+    "family_id" varchar(64),
+    "token_hash" varchar(64),
+    "expires_at" timestamptz,
+    "used_at" timestamptz,
+    "revoked_at" timestamptz,
+    "replaced_by_id" int8,
+    "user_agent" varchar(255),
+    "ip_address" varchar(64),
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_refresh_tokens_token_hash" ON "refresh_tokens" ("token_hash");
+CREATE INDEX IF NOT EXISTS "idx_refresh_tokens_family_id" ON "refresh_tokens" ("family_id");
+CREATE INDEX IF NOT EXISTS "idx_refresh_tokens_user_id" ON "refresh_tokens" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_refresh_tokens_deleted_at" ON "refresh_tokens" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "sessions" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "session_id" varchar(64),
+    "user_id" int8,
+    "device" varchar(255),
+    "user_agent" varchar(255),
+    "ip_address" varchar(64),
+    "last_seen_at" timestamptz,
+    "expires_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_sessions_session_id" ON "sessions" ("session_id");
+CREATE INDEX IF NOT EXISTS "idx_sessions_user_id" ON "sessions" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_sessions_deleted_at" ON "sessions" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "roles" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" varchar(100),
+    "description" text,
+    "is_default" bool,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_roles_name" ON "roles" ("name");
+CREATE INDEX IF NOT EXISTS "idx_roles_deleted_at" ON "roles" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "permissions" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" varchar(100),
+    "description" text,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_permissions_name" ON "permissions" ("name");
+CREATE INDEX IF NOT EXISTS "idx_permissions_deleted_at" ON "permissions" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "role_permissions" (
+    "role_id" bigint,
+    "permission_id" bigint,
+    "created_at" timestamptz,
+    PRIMARY KEY ("role_id", "permission_id"),
+    CONSTRAINT "fk_role_permissions_role" FOREIGN KEY ("role_id") REFERENCES "roles" ("id"),
+    CONSTRAINT "fk_role_permissions_permission" FOREIGN KEY ("permission_id") REFERENCES "permissions" ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "password_reset_tokens" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" int8,
+    "token_hash" varchar(64),
+    "expires_at" timestamptz,
+    "used_at" timestamptz,
+    "request_ip" varchar(64),
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_password_reset_tokens_token_hash" ON "password_reset_tokens" ("token_hash");
+CREATE INDEX IF NOT EXISTS "idx_password_reset_tokens_user_id" ON "password_reset_tokens" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_password_reset_tokens_deleted_at" ON "password_reset_tokens" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "email_verification_tokens" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" int8,
+    "email" varchar(255),
+    "purpose" varchar(20),
+    "token_hash" varchar(64),
+    "expires_at" timestamptz,
+    "used_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_email_verification_tokens_token_hash" ON "email_verification_tokens" ("token_hash");
+CREATE INDEX IF NOT EXISTS "idx_email_verification_tokens_user_id" ON "email_verification_tokens" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_email_verification_tokens_deleted_at" ON "email_verification_tokens" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "outbox_messages" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "kind" varchar(50),
+    "payload" text,
+    "status" varchar(20),
+    "attempts" int8,
+    "max_attempts" int8,
+    "next_attempt_at" timestamptz,
+    "locked_until" timestamptz,
+    "last_error" text,
+    "sent_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_outbox_messages_next_attempt_at" ON "outbox_messages" ("next_attempt_at");
+CREATE INDEX IF NOT EXISTS "idx_outbox_messages_status" ON "outbox_messages" ("status");
+CREATE INDEX IF NOT EXISTS "idx_outbox_messages_deleted_at" ON "outbox_messages" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "provinces" (
+    "province_id" bigint,
+    "province_name" varchar(255),
+    "province_status" varchar(50),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("province_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_provinces_deleted_at" ON "provinces" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "cities" (
+    "city_id" bigint,
+    "province_id" int8,
+    "city_name" varchar(255),
+    "city_status" varchar(50),
+    "postal_code" varchar(10),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("city_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_cities_province_id" ON "cities" ("province_id");
+CREATE INDEX IF NOT EXISTS "idx_cities_deleted_at" ON "cities" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "subdistricts" (
+    "subdistrict_id" bigint,
+    "city_id" int8,
+    "subdistrict_name" varchar(255),
+    "subdistrict_status" varchar(50),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("subdistrict_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_subdistricts_city_id" ON "subdistricts" ("city_id");
+CREATE INDEX IF NOT EXISTS "idx_subdistricts_deleted_at" ON "subdistricts" ("deleted_at");
@@ -0,0 +1,5 @@
+-- Seeders recorded here are skipped on the next `seed` run
+CREATE TABLE IF NOT EXISTS "seeds" (
+    "name" varchar(100) PRIMARY KEY,
+    "applied_at" timestamptz NOT NULL
+);
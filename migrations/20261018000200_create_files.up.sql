@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS "files" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "key" varchar(255),
+    "owner_id" int8,
+    "name" varchar(255),
+    "size" int8,
+    "mime_type" varchar(100),
+    "sha256" varchar(64),
+    "ref_count" int8,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_files_key" ON "files" ("key");
+CREATE INDEX IF NOT EXISTS "idx_files_owner_id" ON "files" ("owner_id");
+CREATE INDEX IF NOT EXISTS "idx_files_sha256" ON "files" ("sha256");
+CREATE INDEX IF NOT EXISTS "idx_files_ref_count" ON "files" ("ref_count");
+CREATE INDEX IF NOT EXISTS "idx_files_deleted_at" ON "files" ("deleted_at");
+
+-- Register the avatars uploaded before files were recorded. Their size, type and
+-- hash are unknown, the owner is the first user pointing at them.
+INSERT INTO "files" ("created_at", "updated_at", "key", "owner_id", "name", "size", "mime_type", "sha256", "ref_count")
+SELECT now(), now(), "image", MIN("id"), "image", 0, '', '', COUNT(*)
+FROM "users"
+WHERE "image" <> ''
+GROUP BY "image"
+ON CONFLICT ("key") DO NOTHING;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS "file_variants" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "file_id" bigint,
+    "name" varchar(50),
+    "key" varchar(255),
+    "width" int8,
+    "height" int8,
+    "size" int8,
+    "mime_type" varchar(100),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_files_variants" FOREIGN KEY ("file_id") REFERENCES "files"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_file_variants_file_id" ON "file_variants" ("file_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_file_variants_key" ON "file_variants" ("key");
+CREATE INDEX IF NOT EXISTS "idx_file_variants_deleted_at" ON "file_variants" ("deleted_at");
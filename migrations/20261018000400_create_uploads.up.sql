@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS "uploads" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "upload_id" varchar(64),
+    "owner_id" int8,
+    "upload_length" int8,
+    "upload_offset" int8,
+    "filename" varchar(255),
+    "metadata" text,
+    "chunks" text,
+    "expires_at" timestamptz,
+    "file_id" int8,
+    "completed_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_uploads_upload_id" ON "uploads" ("upload_id");
+CREATE INDEX IF NOT EXISTS "idx_uploads_owner_id" ON "uploads" ("owner_id");
+CREATE INDEX IF NOT EXISTS "idx_uploads_expires_at" ON "uploads" ("expires_at");
+CREATE INDEX IF NOT EXISTS "idx_uploads_deleted_at" ON "uploads" ("deleted_at");
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_files_scan_status";
+ALTER TABLE "files" DROP COLUMN IF EXISTS "scan_threat";
+ALTER TABLE "files" DROP COLUMN IF EXISTS "scan_status";
@@ -0,0 +1,5 @@
+-- files uploaded before scanning existed are taken as clean
+ALTER TABLE "files" ADD COLUMN IF NOT EXISTS "scan_status" varchar(20) DEFAULT 'clean';
+ALTER TABLE "files" ALTER COLUMN "scan_status" SET DEFAULT 'quarantine';
+ALTER TABLE "files" ADD COLUMN IF NOT EXISTS "scan_threat" varchar(255);
+CREATE INDEX IF NOT EXISTS "idx_files_scan_status" ON "files" ("scan_status");
@@ -0,0 +1,2 @@
+-- the trimmed bodies cannot be restored
+SELECT 1;
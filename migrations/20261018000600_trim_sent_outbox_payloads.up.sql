@@ -0,0 +1,3 @@
+-- delivered emails keep their recipients and subject, the bodies carried sign in links
+UPDATE "outbox_messages" SET "payload" = ("payload"::jsonb - 'Text' - 'HTML')::text
+WHERE "status" = 'sent' AND "kind" = 'email' AND "payload" LIKE '{%';
@@ -0,0 +1,12 @@
+// Package migrations holds the versioned SQL migrations compiled into the binary.
+// Files are named <version>_<name>.up.sql and <version>_<name>.down.sql, where the
+// version is a UTC timestamp (YYYYMMDDHHMMSS) and decides the order they run in.
+// Columns holding a point in time, like an expiry, are timestamptz. pgx writes a
+// plain timestamp as the local wall clock and reads it back as UTC, which shifts
+// every comparison with time.Now() by the host's offset.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS